@@ -0,0 +1,276 @@
+// Package cassette records the HTTP exchanges of the SDK to a file and replays
+// them, so that tests run deterministically without network access.
+//
+//	rec, err := cassette.New("testdata/cards.json", cassette.ModeReplay)
+//	sdk := tcgdex.New(client.WithHTTPClient(rec))
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/internal/atomicfile"
+)
+
+type Mode int
+
+const (
+	// ModeReplay serves recorded responses and fails on any other request.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request upstream and records it, replacing the
+	// cassette.
+	ModeRecord
+	// ModeAuto replays the cassette when the file exists and records it
+	// otherwise.
+	ModeAuto
+)
+
+// ErrUnmatched is returned in replay mode for a request that was not recorded.
+var ErrUnmatched = errors.New("cassette: no recorded interaction")
+
+// UnmatchedError reports a request missing from the cassette.
+type UnmatchedError struct {
+	Method string
+	URL    string
+	Path   string
+}
+
+func (e *UnmatchedError) Error() string {
+	return fmt.Sprintf("%v for %s %s in %s", ErrUnmatched, e.Method, e.URL, e.Path)
+}
+
+func (e *UnmatchedError) Unwrap() error {
+	return ErrUnmatched
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// Response holds a recorded response. Text bodies are stored as is, other
+// bodies in base64.
+type Response struct {
+	Status     int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 []byte      `json:"bodyBase64,omitempty"`
+}
+
+func (r Response) body() []byte {
+	if r.BodyBase64 != nil {
+		return r.BodyBase64
+	}
+	return []byte(r.Body)
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is a client.HTTPClient that records or replays a cassette file.
+type Recorder struct {
+	Path string
+	Mode Mode
+
+	upstream  client.HTTPClient
+	scrubbers []func(http.Header)
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+type Option func(*Recorder)
+
+// WithUpstream sets the client used to reach the API when recording. It
+// defaults to http.DefaultClient.
+func WithUpstream(c client.HTTPClient) Option {
+	return func(r *Recorder) {
+		r.upstream = c
+	}
+}
+
+// WithScrubber adds a hook that edits the request and response headers before
+// they are saved, e.g. to remove credentials. Hooks run in order.
+func WithScrubber(scrub func(http.Header)) Option {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, scrub)
+	}
+}
+
+// ScrubHeaders returns a scrubber that removes the named headers.
+func ScrubHeaders(names ...string) func(http.Header) {
+	return func(h http.Header) {
+		for _, name := range names {
+			h.Del(name)
+		}
+	}
+}
+
+// New opens the cassette at path. In replay mode the file must exist; in
+// record mode it is replaced right away by an empty cassette.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		Path:     path,
+		Mode:     mode,
+		upstream: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.Mode == ModeRecord {
+		r.interactions = []Interaction{}
+		if err := r.save(); err != nil {
+			return nil, err
+		}
+	}
+	if r.Mode == ModeAuto {
+		r.Mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.Mode = ModeReplay
+		}
+	}
+	if r.Mode == ModeReplay {
+		var f cassetteFile
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", path, err)
+		}
+		r.interactions = f.Interactions
+		r.used = make([]bool, len(f.Interactions))
+	}
+	return r, nil
+}
+
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	if r.Mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// Unused returns the recorded interactions that were never replayed, which
+// helps to spot cassettes that no longer match the code under test.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []Interaction
+	for i, used := range r.used {
+		if !used {
+			out = append(out, r.interactions[i])
+		}
+	}
+	return out
+}
+
+// replay answers with the first unused interaction matching the method and
+// URL of req, or with the last matching one once they have all been used.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for i, in := range r.interactions {
+		if in.Request.Method != req.Method || in.Request.URL != req.URL.String() {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, &UnmatchedError{Method: req.Method, URL: req.URL.String(), Path: r.Path}
+	}
+	r.used[match] = true
+	return r.interactions[match].Response.toHTTP(req), nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	resp, err := r.upstream.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	in := Interaction{
+		Request: Request{Method: req.Method, URL: req.URL.String(), Header: req.Header.Clone()},
+		Response: Response{
+			Status: resp.StatusCode,
+			Header: resp.Header.Clone(),
+		},
+	}
+	if utf8.Valid(body) {
+		in.Response.Body = string(body)
+	} else {
+		in.Response.BodyBase64 = body
+	}
+	for _, scrub := range r.scrubbers {
+		scrub(in.Request.Header)
+		scrub(in.Response.Header)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, in)
+	r.used = append(r.used, true)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes every interaction recorded so far, so that the cassette is
+// complete even when the test does not close anything.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(cassetteFile{Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(r.Path, append(data, '\n'))
+}
+
+func (r Response) toHTTP(req *http.Request) *http.Response {
+	body := r.body()
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,136 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/laiambryant/tcgdex"
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/models"
+	"github.com/laiambryant/tcgdex/tcgdextest"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	srv := tcgdextest.NewServer(t, tcgdextest.WithCards(enums.LanguageEn,
+		models.Card{CardResume: models.CardResume{ID: "base1-4", LocalID: "4", Name: "Charizard"}}))
+	path := filepath.Join(t.TempDir(), "cassettes", "cards.json")
+	ctx := context.Background()
+
+	rec, err := New(path, ModeRecord, WithScrubber(ScrubHeaders("User-Agent", "Date")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sdk := tcgdex.New(client.WithBaseURL(srv.BaseURL(enums.LanguageEn)), client.WithHTTPClient(rec),
+		client.WithUserAgent("secret-agent"))
+	if card, err := sdk.Card.Get(ctx, "base1-4"); err != nil || card.Name != "Charizard" {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+	if _, err := sdk.Card.Get(ctx, "base1-999"); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the cassette to be saved: %v", err)
+	}
+	if strings.Contains(string(data), "secret-agent") || strings.Contains(string(data), `"Date"`) {
+		t.Fatalf("expected scrubbed headers to be removed:\n%s", data)
+	}
+	srv.Close()
+
+	replay, err := New(path, ModeAuto)
+	if err != nil || replay.Mode != ModeReplay {
+		t.Fatalf("expected replay mode, got %v %v", replay, err)
+	}
+	sdk = tcgdex.New(client.WithBaseURL(srv.BaseURL(enums.LanguageEn)), client.WithHTTPClient(replay))
+	if len(replay.Unused()) != 2 {
+		t.Fatalf("expected two unused interactions, got %d", len(replay.Unused()))
+	}
+	for range 2 {
+		if card, err := sdk.Card.Get(ctx, "base1-4"); err != nil || card.Name != "Charizard" {
+			t.Fatalf("unexpected replayed card %+v %v", card, err)
+		}
+	}
+	if _, err := sdk.Card.Get(ctx, "base1-999"); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected replayed 404, got %v", err)
+	}
+	if len(replay.Unused()) != 0 {
+		t.Fatalf("expected every interaction to be used, got %v", replay.Unused())
+	}
+
+	_, err = sdk.Set.Get(ctx, "base1")
+	var ue *UnmatchedError
+	if !errors.Is(err, ErrUnmatched) || !errors.As(err, &ue) || ue.Method != http.MethodGet || !strings.HasSuffix(ue.URL, "/sets/base1") {
+		t.Fatalf("expected an unmatched request error, got %v", err)
+	}
+}
+
+func TestBinaryBodies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.json")
+	png := []byte{0x89, 'P', 'N', 'G', 0xff, 0x00}
+	upstream := &fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		resp := client.NewMockResponse(200, string(png))
+		resp.Header.Set("Content-Type", "image/png")
+		return resp, nil
+	}}
+	rec, _ := New(path, ModeRecord, WithUpstream(upstream))
+	c := client.NewHTTPClient(rec)
+	body, err := c.Download(context.Background(), "https://assets.example/base1/4/high.png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body.Close()
+
+	replay, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://assets.example/base1/4/high.png", nil)
+	resp, err := replay.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	buf := make([]byte, 16)
+	n, _ := resp.Body.Read(buf)
+	if string(buf[:n]) != string(png) || resp.Header.Get("Content-Type") != "image/png" {
+		t.Fatalf("unexpected replayed body %v", buf[:n])
+	}
+}
+
+func TestRecordReplacesCassette(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cards.json")
+	if err := os.WriteFile(path, []byte(`{"interactions":[{"request":{"method":"GET","url":"http://old"}}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(path, ModeRecord); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	replay, err := New(path, ModeReplay)
+	if err != nil || len(replay.Unused()) != 0 {
+		t.Fatalf("expected an empty cassette, got %v %v", replay, err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected no temporary files left behind, got %v", entries)
+	}
+}
+
+func TestReplayRequiresCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected a missing file error, got %v", err)
+	}
+}
+
+type fakeHTTP struct {
+	fn func(req *http.Request) (*http.Response, error)
+}
+
+func (f *fakeHTTP) Do(req *http.Request) (*http.Response, error) { return f.fn(req) }
@@ -5,46 +5,70 @@ import (
 	"time"
 )
 
-type cacheEntry struct {
-	data      []byte
-	expiresAt time.Time
+// Entry is a response body stored in a Cache, along with the validators the
+// server sent for it.
+type Entry struct {
+	Data         []byte    `json:"data"`
+	StoredAt     time.Time `json:"storedAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
 }
 
-type Cache struct {
+// Expired reports whether the entry is past its expiry time. Entries without
+// an expiry time never expire.
+func (e Entry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
+// Cache stores response bodies keyed by full request URL. Implementations must
+// be safe for concurrent use. Get may return expired entries; the client checks
+// Entry.Expired itself so that backends are free to keep stale data around.
+type Cache interface {
+	Get(key string) (Entry, bool)
+	Set(key string, entry Entry)
+	Delete(key string)
+	Clear()
+}
+
+// MemoryCache is an unbounded in-memory Cache. It is the default backend used
+// by WithCache.
+type MemoryCache struct {
 	mu    sync.RWMutex
-	items map[string]cacheEntry
-	ttl   time.Duration
+	items map[string]Entry
 }
 
-func NewCache(ttl time.Duration) *Cache {
-	return &Cache{
-		items: make(map[string]cacheEntry),
-		ttl:   ttl,
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		items: make(map[string]Entry),
 	}
 }
 
-func (c *Cache) Get(key string) ([]byte, bool) {
+func (c *MemoryCache) Get(key string) (Entry, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	entry, ok := c.items[key]
-	if !ok {
-		return nil, false
-	}
+	return entry, ok
+}
 
-	if time.Now().After(entry.expiresAt) {
-		return nil, false
-	}
+func (c *MemoryCache) Set(key string, entry Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	return entry.data, true
+	c.items[key] = entry
 }
 
-func (c *Cache) Set(key string, data []byte) {
+func (c *MemoryCache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.items[key] = cacheEntry{
-		data:      data,
-		expiresAt: time.Now().Add(c.ttl),
-	}
+	delete(c.items, key)
+}
+
+func (c *MemoryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]Entry)
 }
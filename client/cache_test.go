@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache()
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected miss on empty cache")
+	}
+	c.Set("a", Entry{Data: []byte("1")})
+	c.Set("b", Entry{Data: []byte("2")})
+	if e, ok := c.Get("a"); !ok || string(e.Data) != "1" {
+		t.Fatalf("unexpected entry %v %v", e, ok)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected deleted entry to be gone")
+	}
+	c.Clear()
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("expected cleared cache to be empty")
+	}
+}
+
+func TestEntryExpired(t *testing.T) {
+	now := time.Now()
+	if (Entry{}).Expired(now) {
+		t.Fatalf("entry without expiry should never expire")
+	}
+	if !(Entry{ExpiresAt: now.Add(-time.Second)}).Expired(now) {
+		t.Fatalf("expected past entry to be expired")
+	}
+	if (Entry{ExpiresAt: now.Add(time.Second)}).Expired(now) {
+		t.Fatalf("expected future entry to be fresh")
+	}
+}
+
+func TestLRUCacheMaxEntries(t *testing.T) {
+	c := NewLRUCache(2, 0)
+	c.Set("a", Entry{Data: []byte("1")})
+	c.Set("b", Entry{Data: []byte("2")})
+	c.Get("a")
+	c.Set("c", Entry{Data: []byte("3")})
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("expected least recently used entry to be evicted")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("expected %s to be kept", k)
+		}
+	}
+	if c.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", c.Len())
+	}
+}
+
+func TestLRUCacheMaxBytes(t *testing.T) {
+	c := NewLRUCache(0, 10)
+	c.Set("a", Entry{Data: []byte("1234")})
+	c.Set("b", Entry{Data: []byte("1234")})
+	if c.Size() != 10 {
+		t.Fatalf("expected size 10, got %d", c.Size())
+	}
+	c.Set("c", Entry{Data: []byte("12")})
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected oldest entry to be evicted for space")
+	}
+	if c.Size() != 8 {
+		t.Fatalf("expected size 8, got %d", c.Size())
+	}
+
+	c.Set("huge", Entry{Data: make([]byte, 20)})
+	if _, ok := c.Get("huge"); ok {
+		t.Fatalf("entries larger than the cache should not be stored")
+	}
+
+	c.Set("b", Entry{Data: []byte("1")})
+	if c.Size() != 5 {
+		t.Fatalf("expected replaced entry to be re-accounted, got %d", c.Size())
+	}
+	c.Delete("b")
+	c.Clear()
+	if c.Len() != 0 || c.Size() != 0 {
+		t.Fatalf("expected empty cache, got %d entries %d bytes", c.Len(), c.Size())
+	}
+}
+
+func TestWithCacheBackend(t *testing.T) {
+	calls := 0
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls++
+		return NewMockResponse(200, req.URL.Path), nil
+	}}
+	lru := NewLRUCache(1, 0)
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithCacheBackend(lru, time.Minute), WithCache(time.Hour))
+	if c.cache != lru || c.cacheTTL != time.Hour {
+		t.Fatalf("WithCache should keep the configured backend and update the ttl")
+	}
+
+	for _, p := range []string{"/a", "/a", "/b", "/a"} {
+		if _, err := c.Get(context.Background(), p); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("expected /a to be evicted by /b, got %d calls", calls)
+	}
+	e, ok := lru.Get("http://example.com/a")
+	if !ok || e.StoredAt.IsZero() || e.ExpiresAt.Sub(e.StoredAt) != time.Hour {
+		t.Fatalf("unexpected stored entry %+v", e)
+	}
+}
@@ -1,28 +1,56 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/laiambryant/tcgdex/enums"
 )
 
+// DefaultBaseURL is the root of the public TCGDex API, without a language segment.
+const DefaultBaseURL = "https://api.tcgdex.net/v2"
+
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
 type Client struct {
 	BaseURL   string
+	Language  enums.Language
 	HTTP      HTTPClient
 	UserAgent string
-	cache     *Cache
+	cache     Cache
+	cacheTTL  time.Duration
+	cacheMode CacheMode
+	maxStale  time.Duration
+	retry     RetryPolicy
+	limiter   *rateLimiter
+	// refreshing holds the keys being revalidated in the background and
+	// flights the requests in progress. Both are shared with clients derived
+	// through ForLanguage.
+	refreshing *sync.Map
+	flights    *flightGroup
+	// refreshTimeout bounds background revalidations, which no caller waits
+	// for.
+	refreshTimeout time.Duration
 }
 
 func NewHTTPClient(httpClient HTTPClient, opts ...Option) *Client {
 	c := &Client{
-		BaseURL:   "https://api.tcgdex.net/v2/en",
-		HTTP:      httpClient,
-		UserAgent: "tcgdex-go-sdk",
+		BaseURL:        DefaultBaseURL + "/" + string(enums.LanguageEn),
+		Language:       enums.LanguageEn,
+		HTTP:           httpClient,
+		UserAgent:      "tcgdex-go-sdk",
+		refreshTimeout: defaultRefreshTimeout,
+		refreshing:     &sync.Map{},
+		flights:        newFlightGroup(),
 	}
 	if c.HTTP == nil {
 		c.HTTP = http.DefaultClient
@@ -34,78 +62,241 @@ func NewHTTPClient(httpClient HTTPClient, opts ...Option) *Client {
 	return c
 }
 
+// ForLanguage returns a copy of the client targeting another locale. The copy
+// shares the HTTP transport and response cache with the original; cache keys
+// are full URLs, so entries for different languages never collide.
+func (c *Client) ForLanguage(lang enums.Language) *Client {
+	clone := *c
+	clone.BaseURL = c.languageURL(lang)
+	clone.Language = lang
+	return &clone
+}
+
+// languageURL replaces the trailing language segment of BaseURL with lang, or
+// appends it when BaseURL does not end with a language.
+func (c *Client) languageURL(lang enums.Language) string {
+	base := strings.TrimSuffix(c.BaseURL, "/")
+	if baseLanguage(base) != "" {
+		base = base[:strings.LastIndex(base, "/")]
+	}
+	return base + "/" + string(lang)
+}
+
+// baseLanguage returns the language a base URL ends with, or "" when its last
+// path segment is not a known language.
+func baseLanguage(baseURL string) enums.Language {
+	base := strings.TrimSuffix(baseURL, "/")
+	lang := enums.Language(base[strings.LastIndex(base, "/")+1:])
+	if !slices.Contains(enums.Languages, lang) {
+		return ""
+	}
+	return lang
+}
+
 func (c *Client) Get(ctx context.Context, path string) ([]byte, error) {
+	resp, err := c.Fetch(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Fetch is like Get but also reports whether the body was served from the
+// cache and whether it is stale. Concurrent fetches of the same URL share a
+// single request to the API.
+func (c *Client) Fetch(ctx context.Context, path string) (*Response, error) {
 	fullURL := c.BaseURL + path
+	var cached *Entry
 	if c.cache != nil {
-		if data, ok := c.cache.Get(fullURL); ok {
-			return data, nil
+		if entry, ok := c.cache.Get(fullURL); ok {
+			now := time.Now()
+			if !entry.Expired(now) {
+				return cachedResponse(entry, false), nil
+			}
+			if c.cacheMode&CacheModeStaleWhileRevalidate != 0 && c.servable(entry, now) {
+				c.revalidateInBackground(ctx, fullURL, entry)
+				return cachedResponse(entry, true), nil
+			}
+			cached = &entry
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
-	if err != nil {
-		return nil, &RequestError{Op: "create request", Err: err}
+	resp, err := c.flights.do(ctx, fullURL, func(ctx context.Context) (*Response, error) {
+		return c.load(ctx, fullURL, cached)
+	})
+	// A caller whose own context ended gets its error rather than stale data.
+	if err != nil && ctx.Err() == nil && cached != nil && c.cacheMode&CacheModeStaleIfError != 0 &&
+		upstreamFailure(err) && c.servable(*cached, time.Now()) {
+		stale := cachedResponse(*cached, true)
+		stale.Err = err
+		return stale, nil
 	}
-	req.Header.Set("User-Agent", c.UserAgent)
+	return resp, err
+}
 
-	resp, err := c.HTTP.Do(req)
+// load requests fullURL from the API, revalidating cached when given, and
+// stores the result in the cache.
+func (c *Client) load(ctx context.Context, fullURL string, cached *Entry) (*Response, error) {
+	resp, err := c.do(ctx, fullURL, conditionalHeader(cached), true, "api error")
 	if err != nil {
-		return nil, &RequestError{Op: "do request", Err: err}
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, &RequestError{Op: "read body", Err: err}
+	if resp.StatusCode == http.StatusNotModified {
+		entry := c.storeEntry(fullURL, *cached, resp.Header)
+		return &Response{Body: entry.Data, StoredAt: entry.StoredAt}, nil
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, ErrNotFound
+	body, _ := io.ReadAll(resp.Body)
+	if c.cache == nil {
+		return &Response{Body: body, StoredAt: time.Now()}, nil
 	}
+	entry := c.storeEntry(fullURL, Entry{Data: body}, resp.Header)
+	return &Response{Body: body, StoredAt: entry.StoredAt}, nil
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, &HTTPError{
-			Status: resp.StatusCode,
-			URL:    fullURL,
-			Body:   string(body),
-			Cause:  errors.New("api error"),
-		}
+// GetUncached requests path from the API, bypassing the response cache and
+// request coalescing. It is meant for routes whose answer changes on every
+// call, such as the random ones.
+func (c *Client) GetUncached(ctx context.Context, path string) ([]byte, error) {
+	resp, err := c.do(ctx, c.BaseURL+path, nil, true, "api error")
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
 
-	if c.cache != nil {
-		c.cache.Set(fullURL, body)
+// conditionalHeader returns the validators to revalidate an expired entry
+// with, or nil when there is nothing to revalidate.
+func conditionalHeader(entry *Entry) http.Header {
+	if entry == nil || (entry.ETag == "" && entry.LastModified == "") {
+		return nil
+	}
+	h := make(http.Header)
+	if entry.ETag != "" {
+		h.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		h.Set("If-Modified-Since", entry.LastModified)
 	}
+	return h
+}
 
-	return body, nil
+// storeEntry caches entry with a fresh expiry time, taking validators from
+// header when the server sent new ones.
+func (c *Client) storeEntry(key string, entry Entry, header http.Header) Entry {
+	now := time.Now()
+	entry.StoredAt = now
+	entry.ExpiresAt = now.Add(c.cacheTTL)
+	if etag := header.Get("ETag"); etag != "" {
+		entry.ETag = etag
+	}
+	if lm := header.Get("Last-Modified"); lm != "" {
+		entry.LastModified = lm
+	}
+	c.cache.Set(key, entry)
+	return entry
 }
 
 func (c *Client) Download(ctx context.Context, urlStr string) (io.ReadCloser, error) {
+	resp, err := c.DownloadResponse(ctx, urlStr)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// DownloadResponse is like Download but returns the whole response, for
+// callers that need its headers. The caller must close the body.
+func (c *Client) DownloadResponse(ctx context.Context, urlStr string) (*http.Response, error) {
+	return c.do(ctx, urlStr, nil, false, "download error")
+}
+
+// RateLimitStats returns the time spent waiting on the rate limiter configured
+// with WithRateLimit. Clients derived with ForLanguage share the limiter and
+// therefore the statistics.
+func (c *Client) RateLimitStats() RateLimitStats {
+	if c.limiter == nil {
+		return RateLimitStats{}
+	}
+	return c.limiter.snapshot()
+}
+
+// do sends a GET request to urlStr with the extra header, retrying according
+// to the client's retry policy, and returns the successful response. When
+// buffer is set the body is read within the attempt so that read failures can
+// be retried too. A 304 answer to a conditional request counts as a success.
+func (c *Client) do(ctx context.Context, urlStr string, header http.Header, buffer bool, cause string) (*http.Response, error) {
+	attempts := max(c.retry.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		resp, retryAfter, err := c.attempt(ctx, urlStr, header, buffer, cause)
+		if err == nil {
+			return resp, nil
+		}
+		var he *HTTPError
+		if errors.As(err, &he) {
+			he.Attempts = attempt
+		}
+		if attempt >= attempts || ctx.Err() != nil || !c.retry.retryable(err) {
+			return nil, err
+		}
+		if werr := sleep(ctx, c.retry.delay(attempt, retryAfter)); werr != nil {
+			return nil, &RequestError{Op: "retry wait", Err: errors.Join(werr, err)}
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, urlStr string, header http.Header, buffer bool, cause string) (*http.Response, time.Duration, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
 	if err != nil {
-		return nil, &RequestError{Op: "create request", Err: err}
+		return nil, 0, &RequestError{Op: "create request", Err: err}
+	}
+	for k, v := range header {
+		req.Header[k] = v
 	}
 	req.Header.Set("User-Agent", c.UserAgent)
 
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, 0, &RequestError{Op: "rate limit", Err: err}
+		}
+	}
+
 	resp, err := c.HTTP.Do(req)
 	if err != nil {
-		return nil, &RequestError{Op: "do request", Err: err}
+		return nil, 0, &RequestError{Op: "do request", Err: err}
+	}
+
+	if buffer {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, 0, &RequestError{Op: "read body", Err: err}
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
 	}
 
 	if resp.StatusCode == http.StatusNotFound {
 		resp.Body.Close()
-		return nil, ErrNotFound
+		return nil, 0, ErrNotFound
+	}
+
+	if resp.StatusCode == http.StatusNotModified && header != nil {
+		return resp, 0, nil
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, &HTTPError{
+		return nil, parseRetryAfter(resp.Header), &HTTPError{
 			Status: resp.StatusCode,
 			URL:    urlStr,
 			Body:   string(body),
-			Cause:  errors.New("download error"),
+			Cause:  errors.New(cause),
 		}
 	}
 
-	return resp.Body, nil
+	return resp, 0, nil
 }
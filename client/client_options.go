@@ -1,6 +1,10 @@
 package client
 
-import "time"
+import (
+	"time"
+
+	"github.com/laiambryant/tcgdex/enums"
+)
 
 type Option func(*Client)
 
@@ -10,15 +14,43 @@ func WithUserAgent(ua string) Option {
 	}
 }
 
+// WithBaseURL sets the URL requests are sent to. When its last path segment is
+// a language, such as ".../v2/fr", Language is set accordingly; otherwise it is
+// left empty and WithLanguage or ForLanguage append the language segment.
 func WithBaseURL(url string) Option {
 	return func(c *Client) {
 		c.BaseURL = url
+		c.Language = baseLanguage(url)
+	}
+}
+
+// WithLanguage points the client at the given locale of the API. When combined
+// with WithBaseURL, the language segment is swapped on whatever base URL is
+// configured at the time the option is applied.
+func WithLanguage(lang enums.Language) Option {
+	return func(c *Client) {
+		c.BaseURL = c.languageURL(lang)
+		c.Language = lang
 	}
 }
 
+// WithCache enables response caching for ttl. Responses are kept in a
+// MemoryCache unless a backend was already chosen with WithCacheBackend.
 func WithCache(ttl time.Duration) Option {
 	return func(c *Client) {
-		c.cache = NewCache(ttl)
+		if c.cache == nil {
+			c.cache = NewMemoryCache()
+		}
+		c.cacheTTL = ttl
+	}
+}
+
+// WithCacheBackend enables response caching for ttl using the given backend,
+// such as an LRUCache.
+func WithCacheBackend(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
 	}
 }
 
@@ -27,3 +59,35 @@ func WithHTTPClient(httpClient HTTPClient) Option {
 		c.HTTP = httpClient
 	}
 }
+
+// WithRetry enables retrying failed requests according to policy. See
+// DefaultRetryPolicy for a sensible starting point.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithRateLimit throttles requests to perSecond on average, allowing bursts of
+// up to burst requests. The limit applies to Get and Download alike, including
+// retries, and callers block until a token is available or their context ends.
+// A perSecond of zero or less is not a valid rate: the option then leaves the
+// client unthrottled.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Client) {
+		if perSecond <= 0 {
+			return
+		}
+		c.limiter = newRateLimiter(perSecond, burst)
+	}
+}
+
+// WithCacheMode controls how expired cache entries are used; see CacheMode.
+// Entries that expired more than maxStale ago are never served, a maxStale of
+// zero or less serves them regardless of age.
+func WithCacheMode(mode CacheMode, maxStale time.Duration) Option {
+	return func(c *Client) {
+		c.cacheMode = mode
+		c.maxStale = maxStale
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/laiambryant/tcgdex/enums"
 )
 
 type errReadCloser struct{}
@@ -228,3 +231,96 @@ func TestWithHTTPClient(t *testing.T) {
 		t.Fatal("http client not set")
 	}
 }
+
+func TestWithLanguage(t *testing.T) {
+	cli := NewHTTPClient(nil, WithLanguage(enums.LanguageFr))
+	if cli.BaseURL != DefaultBaseURL+"/fr" || cli.Language != enums.LanguageFr {
+		t.Fatalf("unexpected language config: %s %s", cli.BaseURL, cli.Language)
+	}
+
+	custom := NewHTTPClient(nil, WithBaseURL("http://example/en/"), WithLanguage(enums.LanguageJa))
+	if custom.BaseURL != "http://example/ja" {
+		t.Fatalf("expected language segment swapped, got %s", custom.BaseURL)
+	}
+
+	bare := NewHTTPClient(nil, WithBaseURL("http://example"), WithLanguage(enums.LanguagePtBr))
+	if bare.BaseURL != "http://example/pt-br" {
+		t.Fatalf("expected language segment appended, got %s", bare.BaseURL)
+	}
+}
+
+func TestWithBaseURLDerivesLanguage(t *testing.T) {
+	fr := NewHTTPClient(nil, WithBaseURL("https://api.tcgdex.net/v2/fr"))
+	if fr.Language != enums.LanguageFr {
+		t.Fatalf("expected language from base URL, got %q", fr.Language)
+	}
+	if ja := fr.ForLanguage(enums.LanguageJa); ja.BaseURL != "https://api.tcgdex.net/v2/ja" || ja.Language != enums.LanguageJa {
+		t.Fatalf("expected language segment swapped, got %s %s", ja.BaseURL, ja.Language)
+	}
+	de := NewHTTPClient(nil, WithBaseURL("https://api.tcgdex.net/v2/fr/"), WithLanguage(enums.LanguageDe))
+	if de.BaseURL != "https://api.tcgdex.net/v2/de" {
+		t.Fatalf("expected language segment swapped, got %s", de.BaseURL)
+	}
+
+	bare := NewHTTPClient(nil, WithBaseURL("http://localhost:8080/v2"))
+	if bare.Language != "" {
+		t.Fatalf("expected no language for a base URL without one, got %q", bare.Language)
+	}
+	if en := bare.ForLanguage(enums.LanguageEn); en.BaseURL != "http://localhost:8080/v2/en" {
+		t.Fatalf("expected language segment appended, got %s", en.BaseURL)
+	}
+}
+
+func TestForLanguageSharesTransportAndCache(t *testing.T) {
+	var urls []string
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		urls = append(urls, req.URL.String())
+		return NewMockResponse(200, `{}`), nil
+	}}
+	httpClient := &http.Client{Transport: mockRT}
+	en := NewHTTPClient(httpClient, WithBaseURL("http://example/en"), WithCache(time.Minute))
+	fr := en.ForLanguage(enums.LanguageFr)
+
+	if fr == en || fr.HTTP != en.HTTP || fr.cache != en.cache {
+		t.Fatalf("expected a distinct client sharing transport and cache")
+	}
+	if en.BaseURL != "http://example/en" || fr.BaseURL != "http://example/fr" {
+		t.Fatalf("unexpected base urls: en=%s fr=%s", en.BaseURL, fr.BaseURL)
+	}
+
+	for _, c := range []*Client{en, fr, en, fr} {
+		if _, err := c.Get(context.Background(), "/cards/x"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	want := []string{"http://example/en/cards/x", "http://example/fr/cards/x"}
+	if strings.Join(urls, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected one request per language, got %v", urls)
+	}
+}
+
+func TestGetUncached(t *testing.T) {
+	calls := 0
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls++
+		if req.URL.Path == "/404" {
+			return NewMockResponse(404, "no"), nil
+		}
+		return NewMockResponse(200, "random"), nil
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithCache(time.Hour))
+	for i := 0; i < 2; i++ {
+		if data, err := c.GetUncached(context.Background(), "/random/card"); err != nil || string(data) != "random" {
+			t.Fatalf("unexpected response %q %v", data, err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected every call to reach the API, got %d", calls)
+	}
+	if _, ok := c.cache.Get("http://example.com/random/card"); ok {
+		t.Fatalf("uncached responses must not be stored")
+	}
+	if _, err := c.GetUncached(context.Background(), "/404"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestConditionalRefresh(t *testing.T) {
+	var gotINM, gotIMS []string
+	modified := false
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		gotINM = append(gotINM, req.Header.Get("If-None-Match"))
+		gotIMS = append(gotIMS, req.Header.Get("If-Modified-Since"))
+		if req.Header.Get("If-None-Match") == `"v1"` && !modified {
+			resp := NewMockResponse(304, "")
+			resp.Header.Set("ETag", `"v1"`)
+			return resp, nil
+		}
+		body, etag := `{"v":1}`, `"v1"`
+		if modified {
+			body, etag = `{"v":2}`, `"v2"`
+		}
+		resp := NewMockResponse(200, body)
+		resp.Header.Set("ETag", etag)
+		resp.Header.Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		return resp, nil
+	}}
+	cache := NewMemoryCache()
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithCacheBackend(cache, time.Hour))
+	key := "http://example.com/sets/base1"
+	expire := func() {
+		e, _ := cache.Get(key)
+		e.ExpiresAt = time.Now().Add(-time.Second)
+		cache.Set(key, e)
+	}
+
+	if data, err := c.Get(context.Background(), "/sets/base1"); err != nil || string(data) != `{"v":1}` {
+		t.Fatalf("unexpected first response %q %v", data, err)
+	}
+	if gotINM[0] != "" || gotIMS[0] != "" {
+		t.Fatalf("first request should not be conditional")
+	}
+
+	expire()
+	data, err := c.Get(context.Background(), "/sets/base1")
+	if err != nil || string(data) != `{"v":1}` {
+		t.Fatalf("expected cached body on 304, got %q %v", data, err)
+	}
+	if gotINM[1] != `"v1"` || gotIMS[1] != "Mon, 02 Jan 2006 15:04:05 GMT" {
+		t.Fatalf("expected validators to be sent, got %q %q", gotINM[1], gotIMS[1])
+	}
+	e, _ := cache.Get(key)
+	if e.Expired(time.Now()) || e.LastModified == "" {
+		t.Fatalf("expected 304 to renew the entry and keep validators, got %+v", e)
+	}
+	if _, err := c.Get(context.Background(), "/sets/base1"); err != nil || len(gotINM) != 2 {
+		t.Fatalf("renewed entry should be served without a request")
+	}
+
+	modified = true
+	expire()
+	data, err = c.Get(context.Background(), "/sets/base1")
+	if err != nil || string(data) != `{"v":2}` {
+		t.Fatalf("expected new body on 200, got %q %v", data, err)
+	}
+	if e, _ := cache.Get(key); e.ETag != `"v2"` || string(e.Data) != `{"v":2}` {
+		t.Fatalf("expected entry to be replaced, got %+v", e)
+	}
+}
+
+func TestConditionalSkippedWithoutValidators(t *testing.T) {
+	var conditional bool
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		conditional = conditional || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != ""
+		return NewMockResponse(304, ""), nil
+	}}
+	cache := NewMemoryCache()
+	cache.Set("http://example.com/x", Entry{Data: []byte("old"), ExpiresAt: time.Now().Add(-time.Second)})
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithCacheBackend(cache, time.Hour))
+
+	_, err := c.Get(context.Background(), "/x")
+	if conditional {
+		t.Fatalf("request should not be conditional without validators")
+	}
+	if he, ok := err.(*HTTPError); !ok || he.Status != 304 {
+		t.Fatalf("unsolicited 304 should be an error, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/laiambryant/tcgdex/internal/atomicfile"
+)
+
+const (
+	diskEntryExt  = ".json"
+	diskTempGlob  = atomicfile.TempPattern
+	diskTempGrace = time.Hour
+)
+
+// DiskCache is a Cache persisted as one file per entry in a directory, so that
+// several runs or processes can share responses. Files are written to a
+// temporary name and renamed into place, which makes concurrent writers safe:
+// readers always see either the old or the new entry in full.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu   sync.Mutex
+	size int64
+}
+
+type diskEntry struct {
+	Key string `json:"key"`
+	Entry
+}
+
+// PruneResult summarizes what DiskCache.Prune removed.
+type PruneResult struct {
+	Removed int
+	Freed   int64
+	// Remaining is the total size of the entries left in the directory.
+	Remaining int64
+}
+
+// NewDiskCache opens or creates a cache in dir. When maxBytes is positive the
+// least recently used entries are pruned whenever the cache grows beyond it.
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := &DiskCache{dir: dir, maxBytes: maxBytes}
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		c.size += f.size
+	}
+	return c, nil
+}
+
+// Dir returns the directory backing the cache.
+func (c *DiskCache) Dir() string {
+	return c.dir
+}
+
+func (c *DiskCache) Get(key string) (Entry, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Entry{}, false
+	}
+	var de diskEntry
+	if err := json.Unmarshal(data, &de); err != nil || de.Key != key {
+		return Entry{}, false
+	}
+	// The modification time doubles as last access time for pruning.
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return de.Entry, true
+}
+
+func (c *DiskCache) Set(key string, entry Entry) {
+	data, err := json.Marshal(diskEntry{Key: key, Entry: entry})
+	if err != nil {
+		return
+	}
+	path := c.path(key)
+	var previous int64
+	if info, err := os.Stat(path); err == nil {
+		previous = info.Size()
+	}
+	if err := atomicfile.Write(path, data); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.size += int64(len(data)) - previous
+	over := c.maxBytes > 0 && c.size > c.maxBytes
+	c.mu.Unlock()
+	if over {
+		_, _ = c.Prune(0)
+	}
+}
+
+func (c *DiskCache) Delete(key string) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	if os.Remove(path) == nil {
+		c.mu.Lock()
+		c.size -= info.Size()
+		c.mu.Unlock()
+	}
+}
+
+func (c *DiskCache) Clear() {
+	files, _ := c.files()
+	for _, f := range files {
+		_ = os.Remove(f.path)
+	}
+	c.mu.Lock()
+	c.size = 0
+	c.mu.Unlock()
+}
+
+// Prune removes entries that have not been used for longer than unusedFor
+// (when positive), then evicts the least recently used entries until the
+// cache fits in its maximum size. Leftover temporary files from interrupted
+// writes are removed as well.
+func (c *DiskCache) Prune(unusedFor time.Duration) (PruneResult, error) {
+	var res PruneResult
+	now := time.Now()
+
+	temps, err := filepath.Glob(filepath.Join(c.dir, diskTempGlob))
+	if err != nil {
+		return res, err
+	}
+	for _, t := range temps {
+		if info, err := os.Stat(t); err == nil && now.Sub(info.ModTime()) > diskTempGrace {
+			_ = os.Remove(t)
+		}
+	}
+
+	files, err := c.files()
+	if err != nil {
+		return res, err
+	}
+	slices.SortFunc(files, func(a, b diskFile) int { return a.modTime.Compare(b.modTime) })
+
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	for _, f := range files {
+		stale := unusedFor > 0 && now.Sub(f.modTime) > unusedFor
+		full := c.maxBytes > 0 && total > c.maxBytes
+		if !stale && !full {
+			continue
+		}
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return res, err
+		}
+		res.Removed++
+		res.Freed += f.size
+		total -= f.size
+	}
+	res.Remaining = total
+
+	c.mu.Lock()
+	c.size = total
+	c.mu.Unlock()
+	return res, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+diskEntryExt)
+}
+
+type diskFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func (c *DiskCache) files() ([]diskFile, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []diskFile
+	for _, de := range dirEntries {
+		name := de.Name()
+		if de.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, diskEntryExt) {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, diskFile{path: filepath.Join(c.dir, name), size: info.Size(), modTime: info.ModTime()})
+	}
+	return files, nil
+}
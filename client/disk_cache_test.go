@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Dir() != dir {
+		t.Fatalf("unexpected dir %s", c.Dir())
+	}
+	if _, ok := c.Get("http://x/a"); ok {
+		t.Fatalf("expected miss on empty cache")
+	}
+
+	stored := time.Now().Truncate(time.Second)
+	c.Set("http://x/a", Entry{Data: []byte(`{"a":1}`), StoredAt: stored, ExpiresAt: stored.Add(time.Hour), ETag: `"v1"`, LastModified: "yesterday"})
+
+	reopened, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e, ok := reopened.Get("http://x/a")
+	if !ok || string(e.Data) != `{"a":1}` || !e.StoredAt.Equal(stored) || e.ETag != `"v1"` || e.LastModified != "yesterday" {
+		t.Fatalf("unexpected entry after reopen: %+v %v", e, ok)
+	}
+
+	c.Delete("http://x/a")
+	if _, ok := c.Get("http://x/a"); ok {
+		t.Fatalf("expected deleted entry to be gone")
+	}
+	c.Set("http://x/b", Entry{Data: []byte("b")})
+	c.Clear()
+	if _, ok := c.Get("http://x/b"); ok {
+		t.Fatalf("expected cleared cache to be empty")
+	}
+}
+
+func TestDiskCacheIgnoresCorruptAndForeignFiles(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(c.path("k"), []byte("garbage"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Fatalf("expected corrupt entry to be a miss")
+	}
+	c.Set("k", Entry{Data: []byte("v")})
+	data, _ := os.ReadFile(c.path("k"))
+	if err := os.WriteFile(c.path("other"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("other"); ok {
+		t.Fatalf("entries must only be returned for their own key")
+	}
+}
+
+func TestDiskCacheConcurrentWriters(t *testing.T) {
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Separate instances stand in for separate processes.
+			c, err := NewDiskCache(dir, 0)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for j := 0; j < 20; j++ {
+				c.Set("shared", Entry{Data: []byte("payload")})
+				if e, ok := c.Get("shared"); ok && string(e.Data) != "payload" {
+					t.Errorf("read torn entry %q", e.Data)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	temps, _ := filepath.Glob(filepath.Join(dir, diskTempGlob))
+	if len(temps) != 0 {
+		t.Fatalf("expected no leftover temp files, got %v", temps)
+	}
+}
+
+func TestDiskCachePrune(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	for _, k := range []string{"a", "b", "c"} {
+		c.Set(k, Entry{Data: make([]byte, 100)})
+	}
+	if err := os.Chtimes(c.path("a"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	tmp := filepath.Join(dir, ".tmp-stale")
+	if err := os.WriteFile(tmp, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(tmp, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.Prune(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Removed != 1 || res.Freed == 0 || res.Remaining == 0 {
+		t.Fatalf("unexpected prune result %+v", res)
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected unused entry to be pruned")
+	}
+	if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+		t.Fatalf("expected stale temp file to be removed")
+	}
+}
+
+func TestDiskCacheMaxBytesEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	probe, _ := NewDiskCache(t.TempDir(), 0)
+	probe.Set("a", Entry{Data: make([]byte, 100)})
+	files, _ := probe.files()
+	entrySize := files[0].size
+
+	c, err := NewDiskCache(dir, 2*entrySize+entrySize/2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	base := time.Now().Add(-time.Hour)
+	for i, k := range []string{"a", "b"} {
+		c.Set(k, Entry{Data: make([]byte, 100)})
+		ts := base.Add(time.Duration(i) * time.Minute)
+		_ = os.Chtimes(c.path(k), ts, ts)
+	}
+	c.Get("a")
+	c.Set("c", Entry{Data: make([]byte, 100)})
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("expected least recently used entry to be evicted")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("expected %s to be kept", k)
+		}
+	}
+}
+
+func TestDiskCacheAsClientBackend(t *testing.T) {
+	calls := 0
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls++
+		resp := NewMockResponse(200, `{"id":"base1"}`)
+		resp.Header.Set("ETag", `"abc"`)
+		resp.Header.Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		return resp, nil
+	}}
+	dir := t.TempDir()
+	disk, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithCacheBackend(disk, time.Hour))
+	if _, err := c.Get(context.Background(), "/sets/base1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A second run starting from the same directory is served from disk.
+	disk2, _ := NewDiskCache(dir, 0)
+	c2 := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithCacheBackend(disk2, time.Hour))
+	data, err := c2.Get(context.Background(), "/sets/base1")
+	if err != nil || string(data) != `{"id":"base1"}` || calls != 1 {
+		t.Fatalf("expected cached response, got %q %v after %d calls", data, err, calls)
+	}
+	e, _ := disk2.Get("http://example.com/sets/base1")
+	if e.ETag != `"abc"` || e.LastModified != "Mon, 02 Jan 2006 15:04:05 GMT" {
+		t.Fatalf("expected validators to be stored, got %+v", e)
+	}
+}
@@ -14,9 +14,14 @@ type HTTPError struct {
 	URL    string
 	Body   string
 	Cause  error
+	// Attempts is the number of requests made before giving up.
+	Attempts int
 }
 
 func (e *HTTPError) Error() string {
+	if e.Attempts > 1 {
+		return fmt.Sprintf("http %d for %s after %d attempts: %s", e.Status, e.URL, e.Attempts, e.Body)
+	}
 	return fmt.Sprintf("http %d for %s: %s", e.Status, e.URL, e.Body)
 }
 
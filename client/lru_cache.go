@@ -0,0 +1,107 @@
+package client
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRUCache is an in-memory Cache bounded by entry count and total size. When a
+// limit is exceeded the least recently used entries are evicted first.
+type LRUCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	size       int64
+	order      *list.List
+	items      map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry Entry
+}
+
+// NewLRUCache returns a cache holding at most maxEntries entries and maxBytes
+// bytes of keys and bodies. A limit of zero or less disables that bound.
+func NewLRUCache(maxEntries int, maxBytes int64) *LRUCache {
+	return &LRUCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (c *LRUCache) Get(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return Entry{}, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+func (c *LRUCache) Set(key string, entry Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(key)
+	n := itemSize(key, entry)
+	if c.maxBytes > 0 && n > c.maxBytes {
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruItem{key: key, entry: entry})
+	c.size += n
+	for (c.maxEntries > 0 && c.order.Len() > c.maxEntries) || (c.maxBytes > 0 && c.size > c.maxBytes) {
+		c.remove(c.order.Back().Value.(*lruItem).key)
+	}
+}
+
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(key)
+}
+
+func (c *LRUCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	c.size = 0
+}
+
+// Len returns the number of cached entries.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// Size returns the number of bytes accounted against maxBytes.
+func (c *LRUCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
+
+func (c *LRUCache) remove(key string) {
+	el, ok := c.items[key]
+	if !ok {
+		return
+	}
+	item := c.order.Remove(el).(*lruItem)
+	delete(c.items, key)
+	c.size -= itemSize(item.key, item.entry)
+}
+
+func itemSize(key string, entry Entry) int64 {
+	return int64(len(key) + len(entry.Data))
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimitStats reports how much time callers spent waiting on the client's
+// rate limiter.
+type RateLimitStats struct {
+	// Requests is the number of requests that went through the limiter.
+	Requests int64
+	// Throttled is the number of requests that had to wait for a token.
+	Throttled int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// rateLimiter is a token bucket refilled at rate tokens per second and holding
+// at most burst tokens.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+}
+
+func newRateLimiter(perSecond float64, burst int) *rateLimiter {
+	burst = max(burst, 1)
+	return &rateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done. The token is reserved
+// up front so that concurrent callers queue up fairly; it is handed back when
+// the context ends before the wait is over.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.stats.Requests++
+	l.mu.Unlock()
+
+	if d <= 0 {
+		return nil
+	}
+
+	start := time.Now()
+	err := sleep(ctx, d)
+	waited := time.Since(start)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err != nil {
+		l.tokens = min(l.burst, l.tokens+1)
+	}
+	l.stats.Throttled++
+	l.stats.TotalWait += waited
+	l.stats.MaxWait = max(l.stats.MaxWait, waited)
+	return err
+}
+
+func (l *rateLimiter) snapshot() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitThrottlesGetAndDownload(t *testing.T) {
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		return NewMockResponse(200, "ok"), nil
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithRateLimit(100, 2))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				if _, err := c.Get(context.Background(), fmt.Sprintf("/x/%d", i)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			rc, err := c.Download(context.Background(), "http://example.com/img")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			rc.Close()
+		}()
+	}
+	wg.Wait()
+
+	// Two requests use the burst, the remaining four wait 10ms each in turn.
+	// How many of them actually wait depends on scheduling, so only lower
+	// bounds are checked.
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, took %v", elapsed)
+	}
+	stats := c.RateLimitStats()
+	if stats.Requests != 6 || stats.Throttled < 1 || stats.Throttled > 4 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if stats.TotalWait <= 0 || stats.MaxWait <= 0 || stats.MaxWait > stats.TotalWait {
+		t.Fatalf("unexpected wait stats: %+v", stats)
+	}
+}
+
+func TestWithRateLimitIgnoresInvalidRate(t *testing.T) {
+	for _, rate := range []float64{0, -5} {
+		c := NewHTTPClient(nil, WithRateLimit(rate, 2))
+		if c.limiter != nil {
+			t.Fatalf("expected rate %v to leave the client unthrottled", rate)
+		}
+	}
+}
+
+func TestRateLimitContextCancel(t *testing.T) {
+	calls := 0
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls++
+		return NewMockResponse(200, "ok"), nil
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithRateLimit(0.5, 1))
+	if _, err := c.Get(context.Background(), "/first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.Get(ctx, "/second")
+	var re *RequestError
+	if !errors.As(err, &re) || re.Op != "rate limit" || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected rate limit RequestError, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("throttled request should not reach the transport, got %d calls", calls)
+	}
+}
+
+func TestRateLimitSharedAcrossLanguages(t *testing.T) {
+	c := NewHTTPClient(nil, WithRateLimit(10, 1))
+	if c.ForLanguage("fr").limiter != c.limiter {
+		t.Fatalf("expected limiter to be shared")
+	}
+	if (NewHTTPClient(nil).RateLimitStats() != RateLimitStats{}) {
+		t.Fatalf("expected empty stats without a limiter")
+	}
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. The zero value makes a
+// single attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the wait before the second attempt; it doubles on every
+	// further attempt up to MaxDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between attempts, including waits requested by
+	// the server through Retry-After. Zero means no limit.
+	MaxDelay time.Duration
+	// Jitter randomizes each delay by up to this fraction of it, in [0, 1].
+	Jitter float64
+	// RetryableStatuses lists the HTTP status codes that are retried.
+	RetryableStatuses []int
+	// RetryableOps lists the RequestError operations that are retried, such as
+	// "do request" or "read body".
+	RetryableOps []string
+}
+
+// DefaultRetryPolicy retries transient server errors and network failures up
+// to three attempts in total.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableOps: []string{"do request", "read body"},
+	}
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	var he *HTTPError
+	if errors.As(err, &he) {
+		return slices.Contains(p.RetryableStatuses, he.Status)
+	}
+	var re *RequestError
+	if errors.As(err, &re) {
+		return slices.Contains(p.RetryableOps, re.Op)
+	}
+	return false
+}
+
+// delay returns the wait before the attempt following attempt. A Retry-After
+// value sent by the server is honoured when it is longer than the backoff, but
+// never beyond MaxDelay, so that a misbehaving server cannot stall the caller.
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 && d > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(d))
+	}
+	if p.MaxDelay > 0 {
+		retryAfter = min(retryAfter, p.MaxDelay)
+	}
+	return max(d, retryAfter)
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an
+// HTTP date.
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// sleep waits for d or until ctx is done, in which case it returns the cause
+// of the cancellation.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return context.Cause(ctx)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
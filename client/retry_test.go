@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fastRetry(attempts int) RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.MaxAttempts = attempts
+	p.BaseDelay = time.Millisecond
+	p.MaxDelay = 2 * time.Millisecond
+	return p
+}
+
+func TestRetryTransientThenSuccess(t *testing.T) {
+	calls := 0
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls++
+		switch calls {
+		case 1:
+			return NewMockResponse(502, "bad gateway"), nil
+		case 2:
+			return nil, errors.New("connection reset")
+		}
+		return NewMockResponse(200, "ok"), nil
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithRetry(fastRetry(3)))
+
+	data, err := c.Get(context.Background(), "/x")
+	if err != nil || string(data) != "ok" {
+		t.Fatalf("expected success after retries, got %q %v", data, err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryExhaustedReportsAttempts(t *testing.T) {
+	calls := 0
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls++
+		return NewMockResponse(503, "down"), nil
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithRetry(fastRetry(4)))
+
+	_, err := c.Get(context.Background(), "/x")
+	var he *HTTPError
+	if !errors.As(err, &he) || he.Status != 503 || he.Attempts != 4 {
+		t.Fatalf("expected HTTPError after 4 attempts, got %#v", err)
+	}
+	if calls != 4 || !strings.Contains(he.Error(), "after 4 attempts") {
+		t.Fatalf("unexpected calls %d or message %q", calls, he.Error())
+	}
+}
+
+func TestRetrySkipsNonRetryable(t *testing.T) {
+	calls := 0
+	mockRT := &MockRoundTripper{}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithRetry(fastRetry(3)))
+
+	mockRT.RoundTripFunc = func(req *http.Request) (*http.Response, error) {
+		calls++
+		return NewMockResponse(400, "bad request"), nil
+	}
+	_, err := c.Get(context.Background(), "/x")
+	var he *HTTPError
+	if !errors.As(err, &he) || he.Attempts != 1 || calls != 1 {
+		t.Fatalf("expected a single attempt for 400, got %d calls err %v", calls, err)
+	}
+
+	calls = 0
+	mockRT.RoundTripFunc = func(req *http.Request) (*http.Response, error) {
+		calls++
+		return NewMockResponse(404, "nope"), nil
+	}
+	if _, err := c.Get(context.Background(), "/x"); err != ErrNotFound || calls != 1 {
+		t.Fatalf("expected a single attempt for 404, got %d calls err %v", calls, err)
+	}
+
+	calls = 0
+	mockRT.RoundTripFunc = func(req *http.Request) (*http.Response, error) {
+		calls++
+		return nil, errors.New("net")
+	}
+	noOps := fastRetry(3)
+	noOps.RetryableOps = nil
+	c2 := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithRetry(noOps))
+	if _, err := c2.Get(context.Background(), "/x"); err == nil || calls != 1 {
+		t.Fatalf("expected a single attempt when ops are not retryable, got %d calls", calls)
+	}
+}
+
+func TestRetryDownload(t *testing.T) {
+	calls := 0
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return NewMockResponse(500, "err"), nil
+		}
+		return NewMockResponse(200, "image"), nil
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithRetry(fastRetry(2)))
+	rc, err := c.Download(context.Background(), "http://ex/img.png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rc.Close()
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryHonoursRetryAfterAndContext(t *testing.T) {
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		resp := NewMockResponse(429, "slow down")
+		resp.Header.Set("Retry-After", "60")
+		return resp, nil
+	}}
+	policy := fastRetry(3)
+	policy.MaxDelay = time.Minute
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithRetry(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.Get(ctx, "/x")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded while waiting, got %v", err)
+	}
+	var he *HTTPError
+	if !errors.As(err, &he) || he.Status != 429 {
+		t.Fatalf("expected last HTTPError to be kept, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("retry wait ignored context cancellation")
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 35 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 35 * time.Millisecond, 10: 35 * time.Millisecond} {
+		if got := p.delay(attempt, 0); got != want {
+			t.Fatalf("attempt %d: want %v got %v", attempt, want, got)
+		}
+	}
+	if got := p.delay(1, 30*time.Millisecond); got != 30*time.Millisecond {
+		t.Fatalf("expected Retry-After to win, got %v", got)
+	}
+	if got := p.delay(1, time.Hour); got != 35*time.Millisecond {
+		t.Fatalf("expected Retry-After to be capped at MaxDelay, got %v", got)
+	}
+	if got := (RetryPolicy{}).delay(1, time.Second); got != time.Second {
+		t.Fatalf("expected Retry-After to be honoured without MaxDelay, got %v", got)
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.delay(1, 0); got < 5*time.Millisecond || got > 15*time.Millisecond {
+			t.Fatalf("jittered delay out of range: %v", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	h := make(http.Header)
+	if parseRetryAfter(h) != 0 {
+		t.Fatalf("expected zero for missing header")
+	}
+	h.Set("Retry-After", "3")
+	if got := parseRetryAfter(h); got != 3*time.Second {
+		t.Fatalf("unexpected seconds value %v", got)
+	}
+	h.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if got := parseRetryAfter(h); got < 59*time.Minute || got > time.Hour {
+		t.Fatalf("unexpected date value %v", got)
+	}
+	h.Set("Retry-After", "soon")
+	if parseRetryAfter(h) != 0 {
+		t.Fatalf("expected zero for invalid header")
+	}
+}
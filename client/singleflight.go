@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup coalesces concurrent loads of the same key into a single call
+// whose result is shared by every caller.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelCauseFunc
+	waiters int
+	resp    *Response
+	err     error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flight)}
+}
+
+// do runs fn once for all concurrent callers with the same key. fn gets a
+// context detached from any single caller: a caller whose ctx ends stops
+// waiting without affecting the others, and fn is only canceled once every
+// caller has given up. The last caller to give up cancels fn with its own
+// context error as the cause and returns whatever fn returns; the others get
+// a "wait for request" error, which is not an upstream failure.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (*Response, error)) (*Response, error) {
+	g.mu.Lock()
+	f, ok := g.calls[key]
+	if !ok {
+		fctx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = f
+		go func() {
+			f.resp, f.err = fn(fctx)
+			g.mu.Lock()
+			if g.calls[key] == f {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			cancel(nil)
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		if f.resp == nil {
+			return nil, f.err
+		}
+		resp := *f.resp
+		return &resp, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		last := f.waiters == 0
+		if last {
+			f.cancel(ctx.Err())
+			if g.calls[key] == f {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		if !last {
+			return nil, &RequestError{Op: "wait for request", Err: ctx.Err()}
+		}
+		<-f.done
+		return f.resp, f.err
+	}
+}
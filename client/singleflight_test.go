@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrentGetsAreCoalesced(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		<-release
+		return NewMockResponse(200, "shared"), nil
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"))
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([]string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := c.Get(context.Background(), "/sets/base1")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = string(data)
+		}()
+	}
+	waitForWaiters(t, c.flights, "http://example.com/sets/base1", n)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single upstream request, got %d", calls.Load())
+	}
+	for i, r := range results {
+		if r != "shared" {
+			t.Fatalf("caller %d got %q", i, r)
+		}
+	}
+}
+
+func TestCoalescedErrorsAreShared(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		<-release
+		return NewMockResponse(500, "down"), nil
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"))
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := c.Get(context.Background(), "/x")
+			errs <- err
+		}()
+	}
+	waitForWaiters(t, c.flights, "http://example.com/x", 2)
+	close(release)
+	for i := 0; i < 2; i++ {
+		var he *HTTPError
+		if err := <-errs; !errors.As(err, &he) || he.Status != 500 {
+			t.Fatalf("expected shared HTTPError, got %v", err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single upstream request, got %d", calls.Load())
+	}
+}
+
+func TestCoalescedCallerCancellation(t *testing.T) {
+	release := make(chan struct{})
+	var canceled atomic.Bool
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-release:
+			return NewMockResponse(200, "ok"), nil
+		case <-req.Context().Done():
+			canceled.Store(true)
+			return nil, req.Context().Err()
+		}
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := c.Get(ctx, "/x")
+		first <- err
+	}()
+	waitForWaiters(t, c.flights, "http://example.com/x", 1)
+	second := make(chan string, 1)
+	go func() {
+		data, err := c.Get(context.Background(), "/x")
+		if err != nil {
+			t.Errorf("second caller should not see the first caller's cancellation: %v", err)
+		}
+		second <- string(data)
+	}()
+	waitForWaiters(t, c.flights, "http://example.com/x", 2)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled caller to return context.Canceled, got %v", err)
+	}
+	close(release)
+	if got := <-second; got != "ok" {
+		t.Fatalf("unexpected body for second caller %q", got)
+	}
+	if canceled.Load() {
+		t.Fatalf("shared request must not be canceled while a caller still waits")
+	}
+}
+
+func TestCoalescedFetchCanceledWhenAllCallersLeave(t *testing.T) {
+	canceled := make(chan struct{})
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		close(canceled)
+		return nil, req.Context().Err()
+	}}
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.Get(ctx, "/x"); err == nil {
+		t.Fatalf("expected error")
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatalf("abandoned request was never canceled")
+	}
+}
+
+func waitForWaiters(t *testing.T, g *flightGroup, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		f, ok := g.calls[key]
+		got := 0
+		if ok {
+			got = f.waiters
+		}
+		g.mu.Unlock()
+		if got >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d callers on %s", n, key)
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// CacheMode selects how expired cache entries are used. Modes can be combined
+// with a bitwise or.
+type CacheMode int
+
+const (
+	// CacheModeStrict treats expired entries as misses (apart from
+	// revalidating them with conditional requests). It is the default.
+	CacheModeStrict CacheMode = 0
+	// CacheModeStaleWhileRevalidate returns expired entries immediately and
+	// refreshes them in the background.
+	CacheModeStaleWhileRevalidate CacheMode = 1 << iota
+	// CacheModeStaleIfError returns expired entries when the upstream answers
+	// with a 5xx status or cannot be reached.
+	CacheModeStaleIfError
+)
+
+// Response is a response body along with where it came from.
+type Response struct {
+	Body []byte
+	// FromCache is set when the body was served from the cache without a
+	// successful round trip to the API.
+	FromCache bool
+	// Stale is set when the body comes from an expired cache entry.
+	Stale bool
+	// StoredAt is when the body was fetched from the API.
+	StoredAt time.Time
+	// Err is the upstream error that caused a stale entry to be served in
+	// CacheModeStaleIfError.
+	Err error
+}
+
+func cachedResponse(entry Entry, stale bool) *Response {
+	return &Response{Body: entry.Data, FromCache: true, Stale: stale, StoredAt: entry.StoredAt}
+}
+
+// servable reports whether an expired entry is recent enough to be served in
+// one of the stale cache modes.
+func (c *Client) servable(entry Entry, now time.Time) bool {
+	return c.maxStale <= 0 || now.Sub(entry.ExpiresAt) <= c.maxStale
+}
+
+// upstreamFailure reports whether err means the API is unavailable rather than
+// that the request itself was wrong.
+func upstreamFailure(err error) bool {
+	var he *HTTPError
+	if errors.As(err, &he) {
+		return he.Status >= 500
+	}
+	var re *RequestError
+	if errors.As(err, &re) {
+		return re.Op == "do request" || re.Op == "read body"
+	}
+	return false
+}
+
+// defaultRefreshTimeout bounds a background revalidation, so that a hung
+// upstream does not block later refreshes of the same key.
+const defaultRefreshTimeout = 30 * time.Second
+
+// revalidateInBackground refreshes key unless a refresh is already running.
+// The refresh outlives ctx's cancellation but keeps its values, gives up after
+// the refresh timeout and shares the request with foreground fetches of key.
+func (c *Client) revalidateInBackground(ctx context.Context, key string, entry Entry) {
+	if _, running := c.refreshing.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer c.refreshing.Delete(key)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.refreshTimeout)
+		defer cancel()
+		_, _ = c.flights.do(ctx, key, func(ctx context.Context) (*Response, error) {
+			return c.load(ctx, key, &entry)
+		})
+	}()
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func expiredCache(key, body string, expiredFor time.Duration) *MemoryCache {
+	cache := NewMemoryCache()
+	stored := time.Now().Add(-time.Hour)
+	cache.Set(key, Entry{Data: []byte(body), StoredAt: stored, ExpiresAt: time.Now().Add(-expiredFor)})
+	return cache
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	release := make(chan struct{})
+	done := make(chan struct{})
+	calls := 0
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls++
+		<-release
+		defer close(done)
+		return NewMockResponse(200, "fresh"), nil
+	}}
+	cache := expiredCache("http://example.com/x", "old", time.Minute)
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"),
+		WithCacheBackend(cache, time.Hour), WithCacheMode(CacheModeStaleWhileRevalidate, time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resp, err := c.Fetch(ctx, "/x")
+	if err != nil || string(resp.Body) != "old" || !resp.Stale || !resp.FromCache {
+		t.Fatalf("expected stale cached response, got %+v %v", resp, err)
+	}
+	// A second caller must not trigger another refresh while one is running.
+	if data, err := c.Get(ctx, "/x"); err != nil || string(data) != "old" {
+		t.Fatalf("expected stale body, got %q %v", data, err)
+	}
+	cancel()
+	close(release)
+	<-done
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		resp, err = c.Fetch(context.Background(), "/x")
+		if err == nil && string(resp.Body) == "fresh" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("background refresh never landed, last %+v %v", resp, err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if resp.Stale || !resp.FromCache || calls != 1 {
+		t.Fatalf("expected fresh cached response after a single refresh, got %+v after %d calls", resp, calls)
+	}
+}
+
+func TestStaleWhileRevalidateTimesOut(t *testing.T) {
+	var calls atomic.Int32
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}}
+	cache := expiredCache("http://example.com/x", "old", time.Minute)
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"),
+		WithCacheBackend(cache, time.Hour), WithCacheMode(CacheModeStaleWhileRevalidate, time.Hour))
+	c.refreshTimeout = 10 * time.Millisecond
+
+	if resp, err := c.Fetch(context.Background(), "/x"); err != nil || !resp.Stale {
+		t.Fatalf("expected stale response, got %+v %v", resp, err)
+	}
+	// The refresh runs through the flight group, so foreground fetches of
+	// the same key share it.
+	waitForWaiters(t, c.flights, "http://example.com/x", 1)
+
+	deadline := time.Now().Add(time.Second)
+	for calls.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("a hung refresh blocked later refreshes, got %d calls", calls.Load())
+		}
+		if _, err := c.Fetch(context.Background(), "/x"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStaleWhileRevalidateRespectsMaxStale(t *testing.T) {
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		return NewMockResponse(200, "fresh"), nil
+	}}
+	cache := expiredCache("http://example.com/x", "old", 2*time.Hour)
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"),
+		WithCacheBackend(cache, time.Hour), WithCacheMode(CacheModeStaleWhileRevalidate, time.Hour))
+
+	resp, err := c.Fetch(context.Background(), "/x")
+	if err != nil || string(resp.Body) != "fresh" || resp.Stale || resp.FromCache {
+		t.Fatalf("entry beyond max staleness should be refetched, got %+v %v", resp, err)
+	}
+}
+
+func TestStaleIfError(t *testing.T) {
+	mockRT := &MockRoundTripper{}
+	cache := expiredCache("http://example.com/x", "old", time.Minute)
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"),
+		WithCacheBackend(cache, time.Hour), WithCacheMode(CacheModeStaleIfError, time.Hour))
+
+	for name, fn := range map[string]func(*http.Request) (*http.Response, error){
+		"5xx":     func(*http.Request) (*http.Response, error) { return NewMockResponse(503, "down"), nil },
+		"network": func(*http.Request) (*http.Response, error) { return nil, errors.New("reset") },
+	} {
+		mockRT.RoundTripFunc = fn
+		resp, err := c.Fetch(context.Background(), "/x")
+		if err != nil || string(resp.Body) != "old" || !resp.Stale || resp.Err == nil {
+			t.Fatalf("%s: expected stale response with upstream error, got %+v %v", name, resp, err)
+		}
+	}
+
+	mockRT.RoundTripFunc = func(*http.Request) (*http.Response, error) { return NewMockResponse(400, "bad"), nil }
+	if _, err := c.Fetch(context.Background(), "/x"); err == nil {
+		t.Fatalf("client errors should not be masked by stale data")
+	}
+	mockRT.RoundTripFunc = func(*http.Request) (*http.Response, error) { return NewMockResponse(404, "gone"), nil }
+	if _, err := c.Fetch(context.Background(), "/x"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	old := expiredCache("http://example.com/x", "old", 2*time.Hour)
+	c2 := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"),
+		WithCacheBackend(old, time.Hour), WithCacheMode(CacheModeStaleIfError, time.Hour))
+	mockRT.RoundTripFunc = func(*http.Request) (*http.Response, error) { return NewMockResponse(500, "down"), nil }
+	if _, err := c2.Fetch(context.Background(), "/x"); err == nil {
+		t.Fatalf("entry beyond max staleness should not be served")
+	}
+}
+
+func TestStaleIfErrorIgnoresCallerCancellation(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	mockRT := &MockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-release:
+			return NewMockResponse(200, "fresh"), nil
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}}
+	cache := expiredCache("http://example.com/x", "old", time.Minute)
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"),
+		WithCacheBackend(cache, time.Hour), WithCacheMode(CacheModeStaleIfError, time.Hour))
+
+	// A second caller keeps the shared request alive, so the first one
+	// leaves as a waiter.
+	go c.Fetch(context.Background(), "/x")
+	waitForWaiters(t, c.flights, "http://example.com/x", 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	resp, err := c.Fetch(ctx, "/x")
+	if !errors.Is(err, context.DeadlineExceeded) || resp != nil {
+		t.Fatalf("expected the waiter's deadline error, got %+v %v", resp, err)
+	}
+
+	// The last caller to leave cancels the request itself.
+	c2 := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"),
+		WithCacheBackend(expiredCache("http://example.com/x", "old", time.Minute), time.Hour),
+		WithCacheMode(CacheModeStaleIfError, time.Hour))
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	if resp, err := c2.Fetch(ctx2, "/x"); err == nil || resp != nil {
+		t.Fatalf("expected the canceled request's error, got %+v %v", resp, err)
+	}
+}
+
+func TestStrictModeDoesNotServeStale(t *testing.T) {
+	mockRT := &MockRoundTripper{RoundTripFunc: func(*http.Request) (*http.Response, error) {
+		return NewMockResponse(500, "down"), nil
+	}}
+	cache := expiredCache("http://example.com/x", "old", time.Minute)
+	c := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com"), WithCacheBackend(cache, time.Hour))
+	if _, err := c.Fetch(context.Background(), "/x"); err == nil {
+		t.Fatalf("expected error in strict mode")
+	}
+
+	mockRT.RoundTripFunc = func(*http.Request) (*http.Response, error) { return NewMockResponse(200, "new"), nil }
+	resp, err := NewHTTPClient(&http.Client{Transport: mockRT}, WithBaseURL("http://example.com")).Fetch(context.Background(), "/x")
+	if err != nil || resp.FromCache || resp.Stale || resp.StoredAt.IsZero() {
+		t.Fatalf("unexpected uncached response %+v %v", resp, err)
+	}
+}
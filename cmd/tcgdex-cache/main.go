@@ -0,0 +1,51 @@
+// Command tcgdex-cache maintains a response cache directory created with
+// client.NewDiskCache, typically one shared between CI jobs.
+//
+// Usage:
+//
+//	tcgdex-cache prune -dir DIR [-max-bytes N] [-unused-for DURATION]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/laiambryant/tcgdex/client"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "tcgdex-cache:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "prune" {
+		return fmt.Errorf("usage: tcgdex-cache prune -dir DIR [-max-bytes N] [-unused-for DURATION]")
+	}
+
+	fs := flag.NewFlagSet("prune", flag.ContinueOnError)
+	dir := fs.String("dir", "", "cache directory")
+	maxBytes := fs.Int64("max-bytes", 0, "maximum total size of the cache in bytes, 0 for unlimited")
+	unusedFor := fs.Duration("unused-for", 0, "remove entries not used for this long, 0 to keep them")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return fmt.Errorf("prune: -dir is required")
+	}
+
+	cache, err := client.NewDiskCache(*dir, *maxBytes)
+	if err != nil {
+		return err
+	}
+	res, err := cache.Prune(*unusedFor)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "removed %d entries (%d bytes), %d bytes remaining\n", res.Removed, res.Freed, res.Remaining)
+	return nil
+}
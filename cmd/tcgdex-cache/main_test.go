@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/laiambryant/tcgdex/client"
+)
+
+func TestRunPrune(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := client.NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"a", "b", "c"} {
+		cache.Set(k, client.Entry{Data: make([]byte, 1000)})
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"prune", "-dir", dir, "-max-bytes", "3500"}, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "removed 1 entries") {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	var out bytes.Buffer
+	if err := run(nil, &out); err == nil {
+		t.Fatalf("expected usage error")
+	}
+	if err := run([]string{"prune"}, &out); err == nil {
+		t.Fatalf("expected missing dir error")
+	}
+}
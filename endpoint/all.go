@@ -0,0 +1,52 @@
+package endpoint
+
+import (
+	"context"
+	"iter"
+
+	"github.com/laiambryant/tcgdex/query"
+)
+
+// DefaultPageSize is the page size All uses when the query does not set one.
+const DefaultPageSize = 100
+
+// All iterates over every item matching q, fetching pages on demand. Iteration
+// starts at the page set on q (or the first page) and stops after an empty or
+// short page. Errors, including context cancellation, are yielded once and end
+// the iteration. q is not modified.
+func (e *Endpoint[T, L]) All(ctx context.Context, q *query.Query) iter.Seq2[L, error] {
+	return func(yield func(L, error) bool) {
+		var zero L
+		if q == nil {
+			q = query.New()
+		}
+		page, size := q.Pagination()
+		if page < 1 {
+			page = 1
+		}
+		if size < 1 {
+			size = DefaultPageSize
+		}
+		pageQuery := q.Clone()
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			items, err := e.List(ctx, pageQuery.SetPage(page, size))
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(items) < size {
+				return
+			}
+			page++
+		}
+	}
+}
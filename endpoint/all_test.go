@@ -0,0 +1,149 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/query"
+)
+
+type pagedItem struct {
+	ID string `json:"id"`
+}
+
+// pagedHTTP serves total items split into pages according to the pagination params.
+func pagedHTTP(t *testing.T, total int, requested *[]string) *fakeHTTP {
+	return &fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		*requested = append(*requested, req.URL.RawQuery)
+		values := req.URL.Query()
+		page, _ := strconv.Atoi(values.Get("pagination:page"))
+		size, _ := strconv.Atoi(values.Get("pagination:itemsPerPage"))
+		if page < 1 || size < 1 {
+			t.Fatalf("missing pagination in %q", req.URL.RawQuery)
+		}
+		var items []string
+		for i := (page-1)*size + 1; i <= page*size && i <= total; i++ {
+			items = append(items, fmt.Sprintf(`{"id":"%d"}`, i))
+		}
+		return client.NewMockResponse(200, "["+strings.Join(items, ",")+"]"), nil
+	}}
+}
+
+func TestAllWalksPages(t *testing.T) {
+	var requested []string
+	c := client.NewHTTPClient(pagedHTTP(t, 5, &requested), client.WithBaseURL("http://example"))
+	e := New[pagedItem, pagedItem](c, "cards")
+
+	q := query.New().Contains("name", "bob").Paginate(1, 2)
+	var ids []string
+	for item, err := range e.All(context.Background(), q) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, item.ID)
+	}
+	if strings.Join(ids, ",") != "1,2,3,4,5" {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+	if len(requested) != 3 {
+		t.Fatalf("expected 3 page requests, got %v", requested)
+	}
+	for _, rq := range requested {
+		if !strings.Contains(rq, "name=bob") || strings.Count(rq, "pagination%3Apage") != 1 {
+			t.Fatalf("unexpected page query %q", rq)
+		}
+	}
+	if page, size := q.Pagination(); page != 1 || size != 2 {
+		t.Fatalf("caller query should be untouched, got page=%d size=%d", page, size)
+	}
+}
+
+func TestAllStopsOnEmptyPage(t *testing.T) {
+	var requested []string
+	c := client.NewHTTPClient(pagedHTTP(t, 4, &requested), client.WithBaseURL("http://example"))
+	e := New[pagedItem, pagedItem](c, "cards")
+
+	count := 0
+	for _, err := range e.All(context.Background(), query.New().Paginate(1, 2)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+	if count != 4 || len(requested) != 3 {
+		t.Fatalf("expected 4 items over 3 requests, got %d items %d requests", count, len(requested))
+	}
+}
+
+func TestAllDefaultsAndEarlyBreak(t *testing.T) {
+	var requested []string
+	c := client.NewHTTPClient(pagedHTTP(t, 1000, &requested), client.WithBaseURL("http://example"))
+	e := New[pagedItem, pagedItem](c, "cards")
+
+	count := 0
+	for _, err := range e.All(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+		if count == DefaultPageSize+1 {
+			break
+		}
+	}
+	if len(requested) != 2 {
+		t.Fatalf("expected iteration to stop after the second page, got %d requests", len(requested))
+	}
+	if !strings.Contains(requested[0], "pagination%3Apage=1") || !strings.Contains(requested[0], "pagination%3AitemsPerPage="+strconv.Itoa(DefaultPageSize)) {
+		t.Fatalf("unexpected default pagination %q", requested[0])
+	}
+}
+
+func TestAllErrors(t *testing.T) {
+	t.Run("Request", func(t *testing.T) {
+		c := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("boom")
+		}}, client.WithBaseURL("http://example"))
+		e := New[pagedItem, pagedItem](c, "cards")
+		var errs int
+		for _, err := range e.All(context.Background(), nil) {
+			var re *client.RequestError
+			if !errors.As(err, &re) {
+				t.Fatalf("expected RequestError, got %v", err)
+			}
+			errs++
+		}
+		if errs != 1 {
+			t.Fatalf("expected a single error, got %d", errs)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		var requested []string
+		c := client.NewHTTPClient(pagedHTTP(t, 10, &requested), client.WithBaseURL("http://example"))
+		e := New[pagedItem, pagedItem](c, "cards")
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var gotErr error
+		for item, err := range e.All(ctx, query.New().Paginate(1, 2)) {
+			if err != nil {
+				gotErr = err
+				break
+			}
+			if item.ID == "2" {
+				cancel()
+			}
+		}
+		if !errors.Is(gotErr, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", gotErr)
+		}
+		if len(requested) != 1 {
+			t.Fatalf("expected no request after cancel, got %v", requested)
+		}
+	})
+}
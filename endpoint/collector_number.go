@@ -0,0 +1,77 @@
+package endpoint
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CollectorNumber is a card number as printed on a card or typed by a user.
+type CollectorNumber struct {
+	// Set is the set code or name given with the number, e.g. "SWSH1" or
+	// "Base Set". It is empty when only the number was given.
+	Set string
+	// LocalID is the number of the card within its set, e.g. "025" or "TG05".
+	LocalID string
+	// Total is the printed set size following the slash, e.g. "202", if any.
+	Total string
+}
+
+// ParseCollectorNumber parses the common ways of writing a card number:
+//
+//	025/202
+//	TG05/TG30
+//	SWSH1 025/202
+//	Base Set 4
+//	swsh1-25
+func ParseCollectorNumber(s string) (CollectorNumber, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return CollectorNumber{}, fmt.Errorf("%w: empty input", ErrInvalidCollectorNumber)
+	}
+
+	var cn CollectorNumber
+	number := fields[len(fields)-1]
+	if len(fields) > 1 {
+		cn.Set = strings.Join(fields[:len(fields)-1], " ")
+	} else if i := strings.LastIndex(number, "-"); i > 0 && !strings.Contains(number, "/") {
+		cn.Set, number = number[:i], number[i+1:]
+	}
+
+	cn.LocalID, cn.Total, _ = strings.Cut(number, "/")
+	if !validLocalID(cn.LocalID) || (strings.Contains(number, "/") && !validLocalID(cn.Total)) {
+		return CollectorNumber{}, fmt.Errorf("%w: %q", ErrInvalidCollectorNumber, s)
+	}
+	return cn, nil
+}
+
+// localIDCandidates returns the local IDs to try for the number, as printed
+// first and then without leading zeros.
+func (cn CollectorNumber) localIDCandidates() []string {
+	ids := []string{cn.LocalID}
+	if trimmed := strings.TrimLeft(cn.LocalID, "0"); trimmed != cn.LocalID && trimmed != "" && isDigits(trimmed) {
+		ids = append(ids, trimmed)
+	}
+	return ids
+}
+
+func validLocalID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package endpoint
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseCollectorNumber(t *testing.T) {
+	cases := map[string]CollectorNumber{
+		"025/202":         {LocalID: "025", Total: "202"},
+		"TG05/TG30":       {LocalID: "TG05", Total: "TG30"},
+		"SWSH1 025/202":   {Set: "SWSH1", LocalID: "025", Total: "202"},
+		"  Base Set   4 ": {Set: "Base Set", LocalID: "4"},
+		"swsh1-25":        {Set: "swsh1", LocalID: "25"},
+		"sv03.5-001":      {Set: "sv03.5", LocalID: "001"},
+		"SV001":           {LocalID: "SV001"},
+	}
+	for in, want := range cases {
+		got, err := ParseCollectorNumber(in)
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("%q: want %+v got %+v", in, want, got)
+		}
+	}
+
+	for _, in := range []string{"", "   ", "025/", "/202", "12#", "Base Set 4/2/1"} {
+		if _, err := ParseCollectorNumber(in); !errors.Is(err, ErrInvalidCollectorNumber) {
+			t.Fatalf("%q: expected ErrInvalidCollectorNumber, got %v", in, err)
+		}
+	}
+}
+
+func TestLocalIDCandidates(t *testing.T) {
+	cases := map[string][]string{
+		"025":  {"025", "25"},
+		"25":   {"25"},
+		"000":  {"000"},
+		"TG05": {"TG05"},
+		"0A":   {"0A"},
+	}
+	for in, want := range cases {
+		if got := (CollectorNumber{LocalID: in}).localIDCandidates(); !slices.Equal(got, want) {
+			t.Fatalf("%q: want %v got %v", in, want, got)
+		}
+	}
+}
@@ -12,6 +12,9 @@ import (
 type Endpoint[T any, L any] struct {
 	Client *client.Client
 	Path   string
+	// Singular names the resource in the /random routes, e.g. "card". It is
+	// empty for resources without a random route.
+	Singular string
 }
 
 func New[T any, L any](c *client.Client, path string) *Endpoint[T, L] {
@@ -21,6 +24,14 @@ func New[T any, L any](c *client.Client, path string) *Endpoint[T, L] {
 	}
 }
 
+// NewResource is like New for a resource with a /random/{singular} route,
+// such as ("cards", "card").
+func NewResource[T any, L any](c *client.Client, path, singular string) *Endpoint[T, L] {
+	e := New[T, L](c, path)
+	e.Singular = singular
+	return e
+}
+
 func (e *Endpoint[T, L]) Get(ctx context.Context, id string) (T, error) {
 	var item T
 	path := fmt.Sprintf("/%s/%s", e.Path, id)
@@ -34,10 +45,15 @@ func (e *Endpoint[T, L]) Get(ctx context.Context, id string) (T, error) {
 	return item, nil
 }
 
+// List returns the items matching q. The query is validated first, so a
+// malformed query fails with a query.ValidationErrors before any request.
 func (e *Endpoint[T, L]) List(ctx context.Context, q *query.Query) ([]L, error) {
 	var items []L
 	qs := ""
 	if q != nil {
+		if err := q.Validate(); err != nil {
+			return nil, err
+		}
 		qs = q.Build()
 	}
 	path := fmt.Sprintf("/%s%s", e.Path, qs)
@@ -50,3 +66,31 @@ func (e *Endpoint[T, L]) List(ctx context.Context, q *query.Query) ([]L, error)
 	}
 	return items, nil
 }
+
+// Random returns a random item from the /random route of the resource, e.g.
+// /random/card for the cards endpoint. The response is never cached. It fails
+// with ErrNoRandomRoute when Singular is empty.
+func (e *Endpoint[T, L]) Random(ctx context.Context) (T, error) {
+	var item T
+	if e.Singular == "" {
+		return item, ErrNoRandomRoute
+	}
+	path := fmt.Sprintf("/random/%s", e.Singular)
+	data, err := e.Client.GetUncached(ctx, path)
+	if err != nil {
+		return item, err
+	}
+	if err := json.Unmarshal(data, &item); err != nil {
+		return item, &DecodeError{Resource: path, Err: err}
+	}
+	return item, nil
+}
+
+// ListTyped is like List but takes a query bound to the endpoint's resource,
+// so that a query built for another resource does not compile.
+func (e *Endpoint[T, L]) ListTyped(ctx context.Context, q *query.Typed[T]) ([]L, error) {
+	if q == nil {
+		return e.List(ctx, nil)
+	}
+	return e.List(ctx, q.Query())
+}
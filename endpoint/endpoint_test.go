@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/models"
 	"github.com/laiambryant/tcgdex/query"
 )
 
@@ -148,3 +149,37 @@ func TestListSuccessNilAndWithQueryAndErrors(t *testing.T) {
 		t.Fatalf("expected request error")
 	}
 }
+
+func TestListTyped(t *testing.T) {
+	c := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		return client.NewMockResponse(200, `[{"id":"`+req.URL.RawQuery+`","localId":"1","name":"x"}]`), nil
+	}}, client.WithBaseURL("http://example"))
+	e := New[models.Card, models.CardResume](c, "cards")
+
+	items, err := e.ListTyped(context.Background(), query.Cards().Where(query.CardFields.HP.GTE(100)))
+	if err != nil || len(items) != 1 || items[0].ID != "hp=gte%3A100" {
+		t.Fatalf("unexpected items %+v %v", items, err)
+	}
+	items, err = e.ListTyped(context.Background(), nil)
+	if err != nil || len(items) != 1 || items[0].ID != "" {
+		t.Fatalf("unexpected items for nil query %+v %v", items, err)
+	}
+}
+
+func TestListValidatesQuery(t *testing.T) {
+	calls := 0
+	c := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		calls++
+		return client.NewMockResponse(200, `[]`), nil
+	}}, client.WithBaseURL("http://example"))
+	e := New[models.Card, models.CardResume](c, "cards")
+
+	_, err := e.List(context.Background(), query.New().Paginate(0, -5))
+	var verrs query.ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) != 2 {
+		t.Fatalf("expected two validation errors, got %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no request for an invalid query, got %d", calls)
+	}
+}
@@ -1,6 +1,9 @@
 package endpoint
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type DecodeError struct {
 	Resource string
@@ -14,3 +17,10 @@ func (e *DecodeError) Error() string {
 func (e *DecodeError) Unwrap() error {
 	return e.Err
 }
+
+var (
+	ErrInvalidCollectorNumber = errors.New("invalid collector number")
+	// ErrNoRandomRoute is returned by Random on an endpoint built without a
+	// singular resource name.
+	ErrNoRandomRoute = errors.New("endpoint has no random route")
+)
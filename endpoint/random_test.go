@@ -0,0 +1,75 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+func TestRandomPathsAndBypassesCache(t *testing.T) {
+	calls := map[string]int{}
+	c := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		calls[req.URL.Path]++
+		return client.NewMockResponse(200, fmt.Sprintf(`{"id":"%s-%d"}`, req.URL.Path, calls[req.URL.Path])), nil
+	}}, client.WithBaseURL("http://example"), client.WithCache(time.Hour))
+
+	cards := NewResource[models.Card, models.CardResume](c, "cards", "card")
+	first, err := cards.Random(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := cards.Random(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.ID == second.ID || calls["/random/card"] != 2 {
+		t.Fatalf("random results must not be cached, got %s and %s", first.ID, second.ID)
+	}
+
+	set, err := NewSet(c).Random(context.Background())
+	if err != nil || set.ID != "/random/set-1" {
+		t.Fatalf("unexpected set %+v %v", set, err)
+	}
+	serie, err := NewResource[models.Serie, models.SerieResume](c, "series", "serie").Random(context.Background())
+	if err != nil || serie.ID != "/random/serie-1" {
+		t.Fatalf("unexpected serie %+v %v", serie, err)
+	}
+}
+
+func TestRandomErrors(t *testing.T) {
+	c := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		return client.NewMockResponse(200, `not-json`), nil
+	}}, client.WithBaseURL("http://example"))
+	var derr *DecodeError
+	if _, err := NewResource[models.Card, models.CardResume](c, "cards", "card").Random(context.Background()); !errors.As(err, &derr) {
+		t.Fatalf("expected DecodeError, got %v", err)
+	}
+
+	c2 := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("boom")
+	}}, client.WithBaseURL("http://example"))
+	var re *client.RequestError
+	if _, err := NewResource[models.Card, models.CardResume](c2, "cards", "card").Random(context.Background()); !errors.As(err, &re) {
+		t.Fatalf("expected RequestError, got %v", err)
+	}
+}
+
+func TestRandomRequiresSingular(t *testing.T) {
+	calls := 0
+	c := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		calls++
+		return client.NewMockResponse(200, `{}`), nil
+	}}, client.WithBaseURL("http://example"))
+	if _, err := New[models.Card, models.CardResume](c, "cards").Random(context.Background()); !errors.Is(err, ErrNoRandomRoute) {
+		t.Fatalf("expected ErrNoRandomRoute, got %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no request, got %d", calls)
+	}
+}
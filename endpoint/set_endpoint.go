@@ -0,0 +1,105 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+// SetEndpoint is the sets endpoint, extended with lookups of cards by their
+// number within a set.
+type SetEndpoint struct {
+	*Endpoint[models.Set, models.SetResume]
+}
+
+func NewSet(c *client.Client) *SetEndpoint {
+	return &SetEndpoint{
+		Endpoint: NewResource[models.Set, models.SetResume](c, "sets", "set"),
+	}
+}
+
+// GetCard returns the card with the given local ID in a set, e.g. ("swsh1", "25").
+func (e *SetEndpoint) GetCard(ctx context.Context, setID, localID string) (models.Card, error) {
+	var item models.Card
+	path := fmt.Sprintf("/%s/%s/%s", e.Path, url.PathEscape(setID), url.PathEscape(localID))
+	data, err := e.Client.Get(ctx, path)
+	if err != nil {
+		return item, err
+	}
+	if err := json.Unmarshal(data, &item); err != nil {
+		return item, &DecodeError{Resource: path, Err: err}
+	}
+	return item, nil
+}
+
+// FindCard returns the card described by a printed collector number that
+// includes its set, such as "SWSH1 025/202" or "Base Set 4"; see
+// ParseCollectorNumber. The set is matched by ID first and then by name,
+// ignoring case. Numbers are tried as printed and without leading zeros.
+func (e *SetEndpoint) FindCard(ctx context.Context, printed string) (models.Card, error) {
+	cn, err := ParseCollectorNumber(printed)
+	if err != nil {
+		return models.Card{}, err
+	}
+	if cn.Set == "" {
+		return models.Card{}, fmt.Errorf("%w: %q has no set", ErrInvalidCollectorNumber, printed)
+	}
+
+	guess := strings.ToLower(cn.Set)
+	card, err := e.getCardByNumber(ctx, guess, cn)
+	if !errors.Is(err, client.ErrNotFound) {
+		return card, err
+	}
+	setID, rerr := e.resolveSet(ctx, cn.Set)
+	if rerr != nil {
+		return models.Card{}, rerr
+	}
+	if setID == guess {
+		return models.Card{}, err
+	}
+	return e.getCardByNumber(ctx, setID, cn)
+}
+
+// FindCardInSet returns the card of a known set from its printed number, e.g.
+// ("swsh1", "025/202") or ("swsh1", "TG05"). Numbers are tried as printed and
+// without leading zeros. A set given within printed is ignored in favour of
+// setID.
+func (e *SetEndpoint) FindCardInSet(ctx context.Context, setID, printed string) (models.Card, error) {
+	cn, err := ParseCollectorNumber(printed)
+	if err != nil {
+		return models.Card{}, err
+	}
+	return e.getCardByNumber(ctx, setID, cn)
+}
+
+func (e *SetEndpoint) getCardByNumber(ctx context.Context, setID string, cn CollectorNumber) (models.Card, error) {
+	var err error
+	for _, localID := range cn.localIDCandidates() {
+		var card models.Card
+		card, err = e.GetCard(ctx, setID, localID)
+		if !errors.Is(err, client.ErrNotFound) {
+			return card, err
+		}
+	}
+	return models.Card{}, err
+}
+
+// resolveSet finds the ID of the set whose ID or name matches name.
+func (e *SetEndpoint) resolveSet(ctx context.Context, name string) (string, error) {
+	sets, err := e.List(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	for _, s := range sets {
+		if strings.EqualFold(s.ID, name) || strings.EqualFold(s.Name, name) {
+			return s.ID, nil
+		}
+	}
+	return "", client.ErrNotFound
+}
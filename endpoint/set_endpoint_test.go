@@ -0,0 +1,109 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/laiambryant/tcgdex/client"
+)
+
+func newSetTestEndpoint(t *testing.T, requested *[]string) *SetEndpoint {
+	c := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		*requested = append(*requested, req.URL.EscapedPath())
+		switch req.URL.EscapedPath() {
+		case "/sets":
+			return client.NewMockResponse(200, `[{"id":"base1","name":"Base Set"},{"id":"swsh1","name":"Sword & Shield"}]`), nil
+		case "/sets/base1/4":
+			return client.NewMockResponse(200, `{"id":"base1-4","localId":"4","name":"Charizard"}`), nil
+		case "/sets/swsh1/25":
+			return client.NewMockResponse(200, `{"id":"swsh1-25","localId":"25","name":"Charmander"}`), nil
+		case "/sets/swsh1/TG05":
+			return client.NewMockResponse(200, `not-json`), nil
+		}
+		return client.NewMockResponse(404, `{}`), nil
+	}}, client.WithBaseURL("http://example"))
+	return NewSet(c)
+}
+
+func TestSetEndpointGetCard(t *testing.T) {
+	var requested []string
+	e := newSetTestEndpoint(t, &requested)
+	if e.Path != "sets" {
+		t.Fatalf("unexpected path %q", e.Path)
+	}
+	card, err := e.GetCard(context.Background(), "base1", "4")
+	if err != nil || card.ID != "base1-4" {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+	var derr *DecodeError
+	if _, err := e.GetCard(context.Background(), "swsh1", "TG05"); !errors.As(err, &derr) {
+		t.Fatalf("expected DecodeError, got %v", err)
+	}
+	if _, err := e.GetCard(context.Background(), "swsh1", "999"); err != client.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSetEndpointFindCard(t *testing.T) {
+	t.Run("SetCodeAndPaddedNumber", func(t *testing.T) {
+		var requested []string
+		e := newSetTestEndpoint(t, &requested)
+		card, err := e.FindCard(context.Background(), "SWSH1 025/202")
+		if err != nil || card.ID != "swsh1-25" {
+			t.Fatalf("unexpected card %+v %v", card, err)
+		}
+		if len(requested) != 2 || requested[0] != "/sets/swsh1/025" {
+			t.Fatalf("expected printed number then unpadded number, got %v", requested)
+		}
+	})
+
+	t.Run("SetName", func(t *testing.T) {
+		var requested []string
+		e := newSetTestEndpoint(t, &requested)
+		card, err := e.FindCard(context.Background(), "Base Set 4")
+		if err != nil || card.ID != "base1-4" {
+			t.Fatalf("unexpected card %+v %v", card, err)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		var requested []string
+		e := newSetTestEndpoint(t, &requested)
+		if _, err := e.FindCard(context.Background(), "025/202"); !errors.Is(err, ErrInvalidCollectorNumber) {
+			t.Fatalf("expected missing set error, got %v", err)
+		}
+		if _, err := e.FindCard(context.Background(), "!!"); !errors.Is(err, ErrInvalidCollectorNumber) {
+			t.Fatalf("expected parse error, got %v", err)
+		}
+		if _, err := e.FindCard(context.Background(), "Jungle 4"); err != client.ErrNotFound {
+			t.Fatalf("expected ErrNotFound for unknown set, got %v", err)
+		}
+		requested = nil
+		if _, err := e.FindCard(context.Background(), "swsh1 999"); err != client.ErrNotFound {
+			t.Fatalf("expected ErrNotFound for unknown card, got %v", err)
+		}
+		if len(requested) != 2 {
+			t.Fatalf("card lookup should not be retried when the set ID was right, got %v", requested)
+		}
+	})
+}
+
+func TestSetEndpointFindCardInSet(t *testing.T) {
+	var requested []string
+	e := newSetTestEndpoint(t, &requested)
+	card, err := e.FindCardInSet(context.Background(), "swsh1", "025/202")
+	if err != nil || card.ID != "swsh1-25" {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+	if len(requested) != 2 || requested[0] != "/sets/swsh1/025" || requested[1] != "/sets/swsh1/25" {
+		t.Fatalf("expected printed number then unpadded number, got %v", requested)
+	}
+	if _, err := e.FindCardInSet(context.Background(), "swsh1", ""); !errors.Is(err, ErrInvalidCollectorNumber) {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+	if _, err := e.FindCardInSet(context.Background(), "base1", "999"); err != client.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+// StringEndpoint serves the secondary resources of the API, such as /types or
+// /illustrators, whose listing is a plain list of values and whose detail
+// route returns the cards matching one value.
+type StringEndpoint struct {
+	Client *client.Client
+	Path   string
+}
+
+func NewString(c *client.Client, path string) *StringEndpoint {
+	return &StringEndpoint{
+		Client: c,
+		Path:   path,
+	}
+}
+
+// List returns every known value. Numeric values, such as hit points, are
+// returned in their decimal form.
+func (e *StringEndpoint) List(ctx context.Context) ([]string, error) {
+	path := fmt.Sprintf("/%s", e.Path)
+	data, err := e.Client.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, &DecodeError{Resource: path, Err: err}
+	}
+	values := make([]string, 0, len(raw))
+	for _, r := range raw {
+		v, err := models.ValueString(r)
+		if err != nil {
+			return nil, &DecodeError{Resource: path, Err: err}
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// Get returns the cards matching value, e.g. "Fire" on the types endpoint.
+func (e *StringEndpoint) Get(ctx context.Context, value string) (models.StringEndpoint, error) {
+	var item models.StringEndpoint
+	path := fmt.Sprintf("/%s/%s", e.Path, url.PathEscape(value))
+	data, err := e.Client.Get(ctx, path)
+	if err != nil {
+		return item, err
+	}
+	if err := json.Unmarshal(data, &item); err != nil {
+		return item, &DecodeError{Resource: path, Err: err}
+	}
+	return item, nil
+}
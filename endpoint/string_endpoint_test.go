@@ -0,0 +1,65 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/laiambryant/tcgdex/client"
+)
+
+func TestStringEndpointList(t *testing.T) {
+	c := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/types":
+			return client.NewMockResponse(200, `["Fire","Water"]`), nil
+		case "/hp":
+			return client.NewMockResponse(200, `[30, 60, 120]`), nil
+		}
+		return client.NewMockResponse(200, `not-json`), nil
+	}}, client.WithBaseURL("http://example"))
+
+	types, err := NewString(c, "types").List(context.Background())
+	if err != nil || len(types) != 2 || types[0] != "Fire" || types[1] != "Water" {
+		t.Fatalf("unexpected types %v %v", types, err)
+	}
+	hp, err := NewString(c, "hp").List(context.Background())
+	if err != nil || len(hp) != 3 || hp[2] != "120" {
+		t.Fatalf("unexpected hp values %v %v", hp, err)
+	}
+	var derr *DecodeError
+	if _, err := NewString(c, "broken").List(context.Background()); !errors.As(err, &derr) {
+		t.Fatalf("expected DecodeError, got %v", err)
+	}
+}
+
+func TestStringEndpointGet(t *testing.T) {
+	c := client.NewHTTPClient(&fakeHTTP{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.URL.EscapedPath() {
+		case "/illustrators/Mitsuhiro%20Arita":
+			return client.NewMockResponse(200, `{"name":"Mitsuhiro Arita","cards":[{"id":"base1-4","localId":"4","name":"Charizard"}]}`), nil
+		case "/hp/60":
+			return client.NewMockResponse(200, `{"name":60,"cards":[]}`), nil
+		case "/types/Broken":
+			return client.NewMockResponse(200, `{"name":true}`), nil
+		}
+		return client.NewMockResponse(404, `{}`), nil
+	}}, client.WithBaseURL("http://example"))
+
+	res, err := NewString(c, "illustrators").Get(context.Background(), "Mitsuhiro Arita")
+	if err != nil || res.Name != "Mitsuhiro Arita" || len(res.Cards) != 1 || res.Cards[0].ID != "base1-4" {
+		t.Fatalf("unexpected illustrator result %+v %v", res, err)
+	}
+	hp, err := NewString(c, "hp").Get(context.Background(), "60")
+	if err != nil || hp.Name != "60" {
+		t.Fatalf("unexpected hp result %+v %v", hp, err)
+	}
+	var derr *DecodeError
+	if _, err := NewString(c, "types").Get(context.Background(), "Broken"); !errors.As(err, &derr) {
+		t.Fatalf("expected DecodeError, got %v", err)
+	}
+	if _, err := NewString(c, "types").Get(context.Background(), "Unknown"); err != client.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -22,6 +22,12 @@ LanguageTh    Language = "th"
 LanguageZhCn  Language = "zh-cn"
 )
 
+// Languages lists every language served by the API.
+var Languages = []Language{
+LanguageEn, LanguageFr, LanguageEs, LanguageEsMx, LanguageIt, LanguagePtBr, LanguagePtPt, LanguageDe,
+LanguageNl, LanguagePl, LanguageRu, LanguageJa, LanguageKo, LanguageZhTw, LanguageId, LanguageTh, LanguageZhCn,
+}
+
 type Extension string
 
 const (
@@ -0,0 +1,341 @@
+package images
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/laiambryant/tcgdex/endpoint"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/internal/atomicfile"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+// DefaultConcurrency is the number of images a Bulk downloads in parallel by
+// default.
+const DefaultConcurrency = 4
+
+// flushInterval is the number of images handled between two saves of the
+// manifest, so that an interrupted run keeps most of its progress.
+const flushInterval = 20
+
+// Bulk downloads the card images of whole sets or series to Dir, as
+// {set}/{localId}.{ext}. A manifest.json in Dir records the size and checksum
+// of every file and the downloads that failed, so that later runs skip the
+// files already present and Retry can fetch the failures again.
+type Bulk struct {
+	Downloader *Downloader
+	Dir        string
+	Quality    enums.Quality
+	Extension  enums.Extension
+
+	concurrency int
+	progress    func(Progress)
+}
+
+type BulkOption func(*Bulk)
+
+// WithFormat selects the quality and format of the images. The default is
+// high quality PNG.
+func WithFormat(quality enums.Quality, extension enums.Extension) BulkOption {
+	return func(b *Bulk) {
+		b.Quality = quality
+		b.Extension = extension
+	}
+}
+
+// WithConcurrency sets how many images are downloaded in parallel.
+func WithConcurrency(n int) BulkOption {
+	return func(b *Bulk) {
+		b.concurrency = max(n, 1)
+	}
+}
+
+// WithProgress registers a callback invoked after each image. Calls are
+// serialized.
+func WithProgress(fn func(Progress)) BulkOption {
+	return func(b *Bulk) {
+		b.progress = fn
+	}
+}
+
+func NewBulk(d *Downloader, dir string, opts ...BulkOption) *Bulk {
+	b := &Bulk{
+		Downloader:  d,
+		Dir:         dir,
+		Quality:     enums.QualityHigh,
+		Extension:   enums.ExtensionPng,
+		concurrency: DefaultConcurrency,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Progress reports the outcome of one image. Done counts the images handled
+// so far out of Total.
+type Progress struct {
+	Done    int
+	Total   int
+	CardID  string
+	Path    string
+	Skipped bool
+	Err     error
+}
+
+// Failure is a download that did not succeed. Path is relative to Bulk.Dir.
+type Failure struct {
+	CardID    string          `json:"cardId"`
+	URL       string          `json:"url"`
+	Path      string          `json:"path"`
+	Extension enums.Extension `json:"extension"`
+	Error     string          `json:"error"`
+}
+
+// FileRecord describes a downloaded file.
+type FileRecord struct {
+	CardID string `json:"cardId"`
+	URL    string `json:"url"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// BulkManifest is the content of manifest.json. Files are keyed by their path
+// relative to Bulk.Dir.
+type BulkManifest struct {
+	Files    map[string]FileRecord `json:"files"`
+	Failures []Failure             `json:"failures"`
+}
+
+// BulkReport summarizes a run.
+type BulkReport struct {
+	Downloaded int
+	Skipped    int
+	// Missing lists the cards without an image.
+	Missing  []string
+	Failures []Failure
+}
+
+type job struct {
+	cardID    string
+	url       string
+	path      string
+	extension enums.Extension
+}
+
+// Set downloads the images of every card of the set.
+func (b *Bulk) Set(ctx context.Context, setID string) (*BulkReport, error) {
+	set, err := endpoint.NewSet(b.Downloader.Client).Get(ctx, setID)
+	if err != nil {
+		return nil, err
+	}
+	jobs, missing := b.jobs(set)
+	return b.run(ctx, jobs, missing)
+}
+
+// Serie downloads the images of every card of every set of the serie.
+func (b *Bulk) Serie(ctx context.Context, serieID string) (*BulkReport, error) {
+	serie, err := endpoint.NewResource[models.Serie, models.SerieResume](b.Downloader.Client, "series", "serie").Get(ctx, serieID)
+	if err != nil {
+		return nil, err
+	}
+	sets := endpoint.NewSet(b.Downloader.Client)
+	var jobs []job
+	var missing []string
+	for _, s := range serie.Sets {
+		set, err := sets.Get(ctx, s.ID)
+		if err != nil {
+			return nil, err
+		}
+		j, m := b.jobs(set)
+		jobs = append(jobs, j...)
+		missing = append(missing, m...)
+	}
+	return b.run(ctx, jobs, missing)
+}
+
+// Retry downloads again the failures recorded in the manifest, in the format
+// each of them was requested in.
+func (b *Bulk) Retry(ctx context.Context) (*BulkReport, error) {
+	manifest, err := b.loadManifest()
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]job, len(manifest.Failures))
+	for i, f := range manifest.Failures {
+		jobs[i] = job{cardID: f.CardID, url: f.URL, path: f.Path, extension: f.Extension}
+	}
+	return b.run(ctx, jobs, nil)
+}
+
+func (b *Bulk) jobs(set models.Set) ([]job, []string) {
+	var jobs []job
+	var missing []string
+	for _, c := range set.Cards {
+		img := Card(c, b.Quality, b.Extension)
+		if img.URL == "" {
+			missing = append(missing, c.ID)
+			continue
+		}
+		name := url.PathEscape(c.LocalID) + "." + string(b.Extension)
+		jobs = append(jobs, job{cardID: c.ID, url: img.URL, path: filepath.Join(url.PathEscape(set.ID), name), extension: b.Extension})
+	}
+	return jobs, missing
+}
+
+// run downloads jobs with bounded concurrency and updates the manifest, which
+// is saved every flushInterval images and at the end. Jobs sharing a path are
+// only downloaded once. Individual failures are reported in the result; an
+// error is returned only when the context ends or the manifest cannot be
+// saved.
+func (b *Bulk) run(ctx context.Context, jobs []job, missing []string) (*BulkReport, error) {
+	manifest, err := b.loadManifest()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(jobs))
+	jobs = slices.DeleteFunc(jobs, func(j job) bool {
+		dup := seen[j.path]
+		seen[j.path] = true
+		return dup
+	})
+
+	report := &BulkReport{Missing: missing}
+	failures := make(map[string]Failure)
+	for _, f := range manifest.Failures {
+		failures[f.Path] = f
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		done     int
+		flushErr error
+		sem      = make(chan struct{}, b.concurrency)
+	)
+	finish := func(j job, skipped bool, rec FileRecord, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		switch {
+		case err != nil:
+			failures[j.path] = Failure{CardID: j.cardID, URL: j.url, Path: j.path, Extension: j.extension, Error: err.Error()}
+		case skipped:
+			report.Skipped++
+			delete(failures, j.path)
+		default:
+			report.Downloaded++
+			manifest.Files[j.path] = rec
+			delete(failures, j.path)
+		}
+		if b.progress != nil {
+			b.progress(Progress{Done: done, Total: len(jobs), CardID: j.cardID, Path: j.path, Skipped: skipped, Err: err})
+		}
+		if done%flushInterval == 0 && flushErr == nil {
+			flushErr = b.saveManifest(manifest, failures)
+		}
+	}
+
+	for _, j := range jobs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			finish(j, false, FileRecord{}, ctx.Err())
+			continue
+		}
+		mu.Lock()
+		prev, known := manifest.Files[j.path]
+		mu.Unlock()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			path := filepath.Join(b.Dir, j.path)
+			if known && prev.URL == j.url && matches(path, prev) {
+				finish(j, true, prev, nil)
+				return
+			}
+			res, err := b.Downloader.Save(ctx, Image{URL: j.url, Extension: j.extension}, path)
+			finish(j, false, FileRecord{CardID: j.cardID, URL: j.url, Size: res.Size, SHA256: res.SHA256}, err)
+		}()
+	}
+	wg.Wait()
+
+	err = b.saveManifest(manifest, failures)
+	for _, f := range manifest.Failures {
+		if seen[f.Path] {
+			report.Failures = append(report.Failures, f)
+		}
+	}
+	if err := cmp.Or(flushErr, err); err != nil {
+		return report, err
+	}
+	return report, ctx.Err()
+}
+
+// saveManifest records failures in manifest, sorted by path, and writes it.
+func (b *Bulk) saveManifest(manifest *BulkManifest, failures map[string]Failure) error {
+	manifest.Failures = manifest.Failures[:0]
+	for _, f := range failures {
+		manifest.Failures = append(manifest.Failures, f)
+	}
+	slices.SortFunc(manifest.Failures, func(a, b Failure) int { return cmp.Compare(a.Path, b.Path) })
+	return writeJSON(filepath.Join(b.Dir, "manifest.json"), manifest)
+}
+
+func (b *Bulk) loadManifest() (*BulkManifest, error) {
+	m := &BulkManifest{}
+	if err := readJSON(filepath.Join(b.Dir, "manifest.json"), m); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]FileRecord)
+	}
+	return m, nil
+}
+
+// matches reports whether the file at path has the size and checksum of rec.
+func matches(path string, rec FileRecord) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err != nil || info.Size() != rec.Size {
+		return false
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false
+	}
+	return hex.EncodeToString(h.Sum(nil)) == rec.SHA256
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(path, append(data, '\n'))
+}
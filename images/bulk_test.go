@@ -0,0 +1,192 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/models"
+	"github.com/laiambryant/tcgdex/tcgdextest"
+)
+
+// flakyAssets serves images with the media type of their extension and fails
+// the paths listed in failing until they are removed.
+type flakyAssets struct {
+	mu       sync.Mutex
+	failing  map[string]bool
+	requests int
+}
+
+func (f *flakyAssets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests++
+	fail := f.failing[r.URL.Path]
+	f.mu.Unlock()
+	if fail {
+		http.Error(w, "boom", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType(enums.Extension(strings.TrimPrefix(path.Ext(r.URL.Path), "."))))
+	w.Write(append(pngData, r.URL.Path...))
+}
+
+func (f *flakyAssets) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
+
+func newBulkFixture(t *testing.T) (*tcgdextest.Server, *flakyAssets) {
+	assets := &flakyAssets{failing: map[string]bool{"/en/base/base1/2/high.png": true}}
+	assetSrv := httptest.NewServer(assets)
+	t.Cleanup(assetSrv.Close)
+
+	img := func(n string) *string { return ptr(assetSrv.URL + "/en/base/base1/" + n) }
+	set := models.Set{
+		SetResume: models.SetResume{ID: "base1", Name: "Base Set"},
+		Serie:     models.SerieResume{ID: "base", Name: "Base"},
+		Cards: []models.CardResume{
+			{ID: "base1-1", LocalID: "1", Name: "Alakazam", Image: img("1")},
+			{ID: "base1-2", LocalID: "2", Name: "Blastoise", Image: img("2")},
+			{ID: "base1-3", LocalID: "3", Name: "Chansey"},
+		},
+	}
+	serie := models.Serie{SerieResume: set.Serie, Sets: []models.SetResume{set.SetResume}}
+	srv := tcgdextest.NewServer(t, tcgdextest.WithSets(enums.LanguageEn, set), tcgdextest.WithSeries(enums.LanguageEn, serie))
+	return srv, assets
+}
+
+func TestBulkSetRetryAndSkip(t *testing.T) {
+	srv, assets := newBulkFixture(t)
+	dir := t.TempDir()
+	var progress []Progress
+	b := NewBulk(New(srv.SDK().Client), dir, WithConcurrency(2), WithProgress(func(p Progress) {
+		progress = append(progress, p)
+	}))
+	ctx := context.Background()
+
+	report, err := b.Set(ctx, "base1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Downloaded != 1 || len(report.Failures) != 1 || report.Failures[0].CardID != "base1-2" ||
+		len(report.Missing) != 1 || report.Missing[0] != "base1-3" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if len(progress) != 2 || progress[1].Done != 2 || progress[1].Total != 2 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "base1", "1.png")); err != nil {
+		t.Fatalf("expected the image to be saved: %v", err)
+	}
+
+	delete(assets.failing, "/en/base/base1/2/high.png")
+	report, err = b.Retry(ctx)
+	if err != nil || report.Downloaded != 1 || len(report.Failures) != 0 {
+		t.Fatalf("unexpected retry report %+v %v", report, err)
+	}
+	manifest, err := b.loadManifest()
+	if err != nil || len(manifest.Failures) != 0 || len(manifest.Files) != 2 {
+		t.Fatalf("unexpected manifest %+v %v", manifest, err)
+	}
+
+	before := assets.count()
+	report, err = NewBulk(New(srv.SDK().Client), dir).Serie(ctx, "base")
+	if err != nil || report.Skipped != 2 || report.Downloaded != 0 {
+		t.Fatalf("expected every image to be skipped, got %+v %v", report, err)
+	}
+	if assets.count() != before {
+		t.Fatalf("expected no asset request for existing files")
+	}
+
+	// A file that no longer matches its checksum is downloaded again.
+	if err := os.WriteFile(filepath.Join(dir, "base1", "2.png"), []byte("corrupt"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	report, err = b.Set(ctx, "base1")
+	if err != nil || report.Skipped != 1 || report.Downloaded != 1 {
+		t.Fatalf("expected the corrupt file to be downloaded again, got %+v %v", report, err)
+	}
+}
+
+func TestBulkCanceledRecordsFailures(t *testing.T) {
+	srv, _ := newBulkFixture(t)
+	dir := t.TempDir()
+	b := NewBulk(New(srv.SDK().Client), dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	set, err := srv.SDK().Set.Get(ctx, "base1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	jobs, _ := b.jobs(set)
+	report, err := b.run(ctx, jobs, nil)
+	if !errors.Is(err, context.Canceled) || len(report.Failures) != 2 {
+		t.Fatalf("expected canceled run with two failures, got %+v %v", report, err)
+	}
+	manifest, _ := b.loadManifest()
+	if len(manifest.Failures) != 2 || manifest.Failures[0].Path != filepath.Join("base1", "1.png") {
+		t.Fatalf("unexpected manifest failures %+v", manifest.Failures)
+	}
+}
+
+func TestBulkRetryKeepsRecordedFormat(t *testing.T) {
+	srv, assets := newBulkFixture(t)
+	assets.failing["/en/base/base1/2/low.webp"] = true
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	report, err := NewBulk(New(srv.SDK().Client), dir, WithFormat(enums.QualityLow, enums.ExtensionWebp)).Set(ctx, "base1")
+	if err != nil || len(report.Failures) != 1 || report.Failures[0].Extension != enums.ExtensionWebp {
+		t.Fatalf("unexpected report %+v %v", report, err)
+	}
+
+	delete(assets.failing, "/en/base/base1/2/low.webp")
+	report, err = NewBulk(New(srv.SDK().Client), dir).Retry(ctx)
+	if err != nil || report.Downloaded != 1 || len(report.Failures) != 0 {
+		t.Fatalf("expected the retry to use webp, got %+v %v", report, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "base1", "2.webp")); err != nil {
+		t.Fatalf("expected the webp image to be saved: %v", err)
+	}
+}
+
+func TestBulkFlushesManifestDuringRun(t *testing.T) {
+	assetSrv := httptest.NewServer(&flakyAssets{})
+	t.Cleanup(assetSrv.Close)
+	jobs := make([]job, flushInterval+2)
+	for i := range jobs {
+		n := strconv.Itoa(i)
+		jobs[i] = job{cardID: "base1-" + n, url: assetSrv.URL + "/base1/" + n + ".png", path: filepath.Join("base1", n+".png"), extension: enums.ExtensionPng}
+	}
+
+	dir := t.TempDir()
+	var b *Bulk
+	saved := -1
+	b = NewBulk(New(client.NewHTTPClient(http.DefaultClient)), dir, WithConcurrency(1), WithProgress(func(p Progress) {
+		if p.Done == flushInterval+1 {
+			manifest, err := b.loadManifest()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			saved = len(manifest.Files)
+		}
+	}))
+	if _, err := b.run(context.Background(), jobs, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved != flushInterval {
+		t.Fatalf("expected %d files saved before the run ended, got %d", flushInterval, saved)
+	}
+}
@@ -0,0 +1,139 @@
+// Package images downloads card images and set and serie logos from the
+// TCGDex asset server.
+package images
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/internal/atomicfile"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+// ErrNoImage is returned when downloading an image the resource does not
+// have, such as the logo of a set without one.
+var ErrNoImage = errors.New("resource has no image")
+
+// ContentTypeError reports a download whose Content-Type does not match the
+// requested format.
+type ContentTypeError struct {
+	URL  string
+	Want string
+	Got  string
+}
+
+func (e *ContentTypeError) Error() string {
+	return fmt.Sprintf("unexpected content type %q for %s, want %q", e.Got, e.URL, e.Want)
+}
+
+// Image is an asset to download. URL is empty when the resource has no image.
+type Image struct {
+	URL       string
+	Extension enums.Extension
+}
+
+func newImage(url *string, extension enums.Extension) Image {
+	if url == nil {
+		return Image{Extension: extension}
+	}
+	return Image{URL: *url, Extension: extension}
+}
+
+// Card returns the image of a card in the given quality and format.
+func Card(card models.CardResume, quality enums.Quality, extension enums.Extension) Image {
+	return newImage(card.GetImageURL(quality, extension), extension)
+}
+
+// SetLogo returns the logo of a set. Logos come in a single quality.
+func SetLogo(set models.SetResume, extension enums.Extension) Image {
+	return newImage(set.GetLogoURL(extension), extension)
+}
+
+// SetSymbol returns the symbol of a set. Symbols come in a single quality.
+func SetSymbol(set models.SetResume, extension enums.Extension) Image {
+	return newImage(set.GetSymbolURL(extension), extension)
+}
+
+// SerieLogo returns the logo of a serie. Logos come in a single quality.
+func SerieLogo(serie models.SerieResume, extension enums.Extension) Image {
+	return newImage(serie.GetLogoURL(extension), extension)
+}
+
+// Result describes a downloaded image.
+type Result struct {
+	URL         string
+	ContentType string
+	Size        int64
+	// SHA256 is the hex-encoded checksum of the image.
+	SHA256 string
+}
+
+// Downloader fetches images through a client, benefiting from its retry
+// policy and rate limit.
+type Downloader struct {
+	Client *client.Client
+}
+
+func New(c *client.Client) *Downloader {
+	return &Downloader{Client: c}
+}
+
+// Write downloads img to w. The Content-Type of the response is checked
+// before anything is written.
+func (d *Downloader) Write(ctx context.Context, img Image, w io.Writer) (Result, error) {
+	res := Result{URL: img.URL}
+	if img.URL == "" {
+		return res, ErrNoImage
+	}
+	resp, err := d.Client.DownloadResponse(ctx, img.URL)
+	if err != nil {
+		return res, err
+	}
+	defer resp.Body.Close()
+
+	res.ContentType = resp.Header.Get("Content-Type")
+	want := contentType(img.Extension)
+	if got, _, _ := mime.ParseMediaType(res.ContentType); got != want {
+		return res, &ContentTypeError{URL: img.URL, Want: want, Got: res.ContentType}
+	}
+
+	h := sha256.New()
+	res.Size, err = io.Copy(io.MultiWriter(w, h), resp.Body)
+	if err != nil {
+		return res, err
+	}
+	res.SHA256 = hex.EncodeToString(h.Sum(nil))
+	return res, nil
+}
+
+// Save downloads img to path, creating parent directories. The file is
+// written atomically: it is left untouched when the download fails.
+func (d *Downloader) Save(ctx context.Context, img Image, path string) (Result, error) {
+	if img.URL == "" {
+		return Result{}, ErrNoImage
+	}
+	var res Result
+	err := atomicfile.WriteFunc(path, func(w io.Writer) error {
+		var err error
+		res, err = d.Write(ctx, img, w)
+		return err
+	})
+	return res, err
+}
+
+// contentType returns the media type served for extension.
+func contentType(extension enums.Extension) string {
+	switch extension {
+	case enums.ExtensionJpg:
+		return "image/jpeg"
+	default:
+		return "image/" + string(extension)
+	}
+}
@@ -0,0 +1,111 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+var pngData = []byte("\x89PNG\r\n\x1a\nfake image")
+
+// newAssetServer serves pngData as PNG for .png paths and as the wrong type
+// for anything else.
+func newAssetServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch filepath.Ext(r.URL.Path) {
+		case ".png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(pngData)
+		case ".gone":
+			http.NotFound(w, r)
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html>"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestImageConstructors(t *testing.T) {
+	base := "https://assets.tcgdex.net/en/base/base1"
+	card := models.CardResume{ID: "base1-4", Image: ptr(base + "/4")}
+	set := models.SetResume{ID: "base1", Logo: ptr(base + "/logo"), Symbol: ptr("https://assets.tcgdex.net/univ/base/base1/symbol")}
+	serie := models.SerieResume{ID: "base", Logo: ptr("https://assets.tcgdex.net/en/base/logo")}
+
+	cases := []struct {
+		img  Image
+		want string
+	}{
+		{Card(card, enums.QualityHigh, enums.ExtensionPng), base + "/4/high.png"},
+		{SetLogo(set, enums.ExtensionWebp), base + "/logo.webp"},
+		{SetSymbol(set, enums.ExtensionJpg), "https://assets.tcgdex.net/univ/base/base1/symbol.jpg"},
+		{SerieLogo(serie, enums.ExtensionPng), "https://assets.tcgdex.net/en/base/logo.png"},
+		{Card(models.CardResume{}, enums.QualityLow, enums.ExtensionPng), ""},
+	}
+	for _, tc := range cases {
+		if tc.img.URL != tc.want {
+			t.Fatalf("got %q, want %q", tc.img.URL, tc.want)
+		}
+	}
+}
+
+func TestWriteAndSave(t *testing.T) {
+	srv := newAssetServer(t)
+	d := New(client.NewHTTPClient(nil))
+	ctx := context.Background()
+	sum := sha256.Sum256(pngData)
+	wantSum := hex.EncodeToString(sum[:])
+
+	card := models.CardResume{ID: "base1-4", Image: ptr(srv.URL + "/en/base/base1/4")}
+	var buf bytes.Buffer
+	res, err := d.Write(ctx, Card(card, enums.QualityHigh, enums.ExtensionPng), &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), pngData) || res.Size != int64(len(pngData)) || res.SHA256 != wantSum || res.ContentType != "image/png" {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	path := filepath.Join(t.TempDir(), "base1", "4.png")
+	if res, err := d.Save(ctx, Card(card, enums.QualityHigh, enums.ExtensionPng), path); err != nil || res.SHA256 != wantSum {
+		t.Fatalf("unexpected save result %+v %v", res, err)
+	}
+	if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, pngData) {
+		t.Fatalf("unexpected file content %q %v", data, err)
+	}
+
+	// A failed download leaves the existing file and no temporary file.
+	_, err = d.Save(ctx, Card(card, enums.QualityHigh, enums.ExtensionWebp), path)
+	var cte *ContentTypeError
+	if !errors.As(err, &cte) || cte.Want != "image/webp" || cte.Got != "text/html; charset=utf-8" {
+		t.Fatalf("expected a content type error, got %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("expected only the saved image to remain, got %d entries", len(entries))
+	}
+	if data, _ := os.ReadFile(path); !bytes.Equal(data, pngData) {
+		t.Fatalf("expected the existing file to be kept")
+	}
+
+	if _, err := d.Write(ctx, Image{URL: srv.URL + "/missing.gone", Extension: enums.ExtensionPng}, &buf); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := d.Save(ctx, SetLogo(models.SetResume{}, enums.ExtensionPng), path); !errors.Is(err, ErrNoImage) {
+		t.Fatalf("expected ErrNoImage, got %v", err)
+	}
+}
+
+func ptr(s string) *string { return &s }
@@ -0,0 +1,47 @@
+// Package atomicfile replaces files atomically, so that an interrupted
+// program never leaves a truncated file behind.
+package atomicfile
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// TempPattern is the name pattern of the temporary files, which are created
+// next to their target and removed when a write fails.
+const TempPattern = ".tmp-*"
+
+// Write replaces the file at path with data, creating parent directories.
+func Write(path string, data []byte) error {
+	return WriteFunc(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// WriteFunc replaces the file at path with what write produces, creating
+// parent directories. The file is left untouched when write fails.
+func WriteFunc(path string, write func(io.Writer) error) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, TempPattern)
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	err = write(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
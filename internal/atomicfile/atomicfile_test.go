@@ -0,0 +1,44 @@
+package atomicfile
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a", "b.json")
+	if err := Write(path, []byte("one")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Write(path, []byte("two")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "two" {
+		t.Fatalf("unexpected content %q %v", data, err)
+	}
+}
+
+func TestWriteFuncKeepsFileOnError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "f")
+	if err := Write(path, []byte("kept")); err != nil {
+		t.Fatal(err)
+	}
+	boom := errors.New("boom")
+	err := WriteFunc(path, func(w io.Writer) error {
+		w.Write([]byte("partial"))
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected the write error, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "kept" {
+		t.Fatalf("expected the file to be untouched, got %q", data)
+	}
+	if temps, _ := filepath.Glob(filepath.Join(dir, TempPattern)); len(temps) != 0 {
+		t.Fatalf("expected temporary files to be removed, got %v", temps)
+	}
+}
@@ -0,0 +1,84 @@
+package tcgdex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/endpoint"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+// LanguageError reports a failed lookup in one language.
+type LanguageError struct {
+	Language enums.Language
+	Err      error
+}
+
+func (e *LanguageError) Error() string {
+	return fmt.Sprintf("language %s: %v", e.Language, e.Err)
+}
+
+func (e *LanguageError) Unwrap() error {
+	return e.Err
+}
+
+// GetCardLocalized fetches the card with the given ID in every requested
+// language concurrently. Languages in which the card does not exist are listed
+// in Missing instead of failing the lookup; client.ErrNotFound is returned only
+// when the card is missing from all of them. Any other failure is returned as a
+// *LanguageError, joined when several languages fail. With no languages, every
+// language in enums.Languages is queried.
+func (t *TCGDex) GetCardLocalized(ctx context.Context, id string, langs ...enums.Language) (*models.LocalizedCard, error) {
+	type result struct {
+		lang enums.Language
+		card models.Card
+		err  error
+	}
+
+	if len(langs) == 0 {
+		langs = enums.Languages
+	}
+
+	results := make([]result, len(langs))
+	var wg sync.WaitGroup
+	for i, lang := range langs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cards := endpoint.NewResource[models.Card, models.CardResume](t.Client.ForLanguage(lang), "cards", "card")
+			card, err := cards.Get(ctx, id)
+			results[i] = result{lang: lang, card: card, err: err}
+		}()
+	}
+	wg.Wait()
+
+	localized := &models.LocalizedCard{
+		ID:    id,
+		Cards: make(map[enums.Language]models.Card, len(langs)),
+	}
+	var errs []error
+	for _, r := range results {
+		switch {
+		case r.err == nil:
+			localized.Cards[r.lang] = r.card
+		case errors.Is(r.err, client.ErrNotFound):
+			if !slices.Contains(localized.Missing, r.lang) {
+				localized.Missing = append(localized.Missing, r.lang)
+			}
+		default:
+			errs = append(errs, &LanguageError{Language: r.lang, Err: r.err})
+		}
+	}
+	if len(errs) > 0 {
+		return localized, errors.Join(errs...)
+	}
+	if len(localized.Cards) == 0 {
+		return localized, client.ErrNotFound
+	}
+	return localized, nil
+}
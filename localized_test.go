@@ -0,0 +1,83 @@
+package tcgdex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+)
+
+type fakeLocalizedClient struct{}
+
+func (f *fakeLocalizedClient) Do(req *http.Request) (*http.Response, error) {
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/en/"):
+		return client.NewMockResponse(200, `{"id":"base1-4","localId":"4","name":"Charizard","attacks":[{"name":"Fire Spin"}]}`), nil
+	case strings.HasPrefix(req.URL.Path, "/fr/"):
+		return client.NewMockResponse(200, `{"id":"base1-4","localId":"4","name":"Dracaufeu","attacks":[{"name":"Danse du feu"}]}`), nil
+	case strings.HasPrefix(req.URL.Path, "/de/"):
+		return client.NewMockResponse(500, "boom"), nil
+	}
+	return client.NewMockResponse(404, `{"error":"not found"}`), nil
+}
+
+func TestGetCardLocalized(t *testing.T) {
+	sdk := New(client.WithBaseURL("http://example/en"), client.WithHTTPClient(&fakeLocalizedClient{}))
+
+	t.Run("MergesAndReportsMissing", func(t *testing.T) {
+		lc, err := sdk.GetCardLocalized(context.Background(), "base1-4", enums.LanguageEn, enums.LanguageFr, enums.LanguageJa)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names := lc.Names()
+		if len(names) != 2 || names[enums.LanguageEn] != "Charizard" || names[enums.LanguageFr] != "Dracaufeu" {
+			t.Fatalf("unexpected names: %v", names)
+		}
+		if len(lc.Missing) != 1 || lc.Missing[0] != enums.LanguageJa {
+			t.Fatalf("expected ja to be missing, got %v", lc.Missing)
+		}
+		if attacks := lc.AttackNames()[enums.LanguageFr]; len(attacks) != 1 || attacks[0] != "Danse du feu" {
+			t.Fatalf("unexpected french attacks: %v", attacks)
+		}
+	})
+
+	t.Run("AllMissing", func(t *testing.T) {
+		lc, err := sdk.GetCardLocalized(context.Background(), "base1-4", enums.LanguageJa, enums.LanguageKo)
+		if err != client.ErrNotFound {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+		if len(lc.Missing) != 2 {
+			t.Fatalf("expected both languages missing, got %v", lc.Missing)
+		}
+	})
+
+	t.Run("DefaultsToEveryLanguage", func(t *testing.T) {
+		lc, err := sdk.GetCardLocalized(context.Background(), "base1-4")
+		var le *LanguageError
+		if !errors.As(err, &le) || le.Language != enums.LanguageDe {
+			t.Fatalf("expected LanguageError for de, got %v", err)
+		}
+		if got := len(lc.Cards) + len(lc.Missing) + 1; got != len(enums.Languages) {
+			t.Fatalf("expected every language to be queried, got %d of %d", got, len(enums.Languages))
+		}
+	})
+
+	t.Run("OtherErrorsFail", func(t *testing.T) {
+		lc, err := sdk.GetCardLocalized(context.Background(), "base1-4", enums.LanguageEn, enums.LanguageDe)
+		var le *LanguageError
+		if !errors.As(err, &le) || le.Language != enums.LanguageDe {
+			t.Fatalf("expected LanguageError for de, got %v", err)
+		}
+		var he *client.HTTPError
+		if !errors.As(err, &he) || he.Status != 500 {
+			t.Fatalf("expected wrapped HTTPError, got %v", err)
+		}
+		if _, ok := lc.Cards[enums.LanguageEn]; !ok {
+			t.Fatalf("expected successful languages to be kept")
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package mirror
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/internal/atomicfile"
+)
+
+// Layout maps resources to files in a mirror directory:
+//
+//	manifest.json
+//	{lang}/series.json        list of models.SerieResume
+//	{lang}/sets.json          list of models.SetResume
+//	{lang}/cards.json         list of models.CardResume
+//	{lang}/series/{id}.json   models.Serie
+//	{lang}/sets/{id}.json     models.Set
+//	{lang}/cards/{id}.json    models.Card
+//
+// IDs are path-escaped so that every ID maps to a single file name.
+type Layout struct {
+	Dir string
+}
+
+func (l Layout) Manifest() string {
+	return filepath.Join(l.Dir, "manifest.json")
+}
+
+// List returns the file holding the listing of a resource, where resource is
+// "series", "sets" or "cards" as in the API paths.
+func (l Layout) List(lang enums.Language, resource string) string {
+	return filepath.Join(l.Dir, string(lang), resource+".json")
+}
+
+// Item returns the file holding one item of a resource.
+func (l Layout) Item(lang enums.Language, resource, id string) string {
+	return filepath.Join(l.Dir, string(lang), resource, url.PathEscape(id)+".json")
+}
+
+func (l Layout) Serie(lang enums.Language, id string) string {
+	return l.Item(lang, "series", id)
+}
+
+func (l Layout) Set(lang enums.Language, id string) string {
+	return l.Item(lang, "sets", id)
+}
+
+func (l Layout) Card(lang enums.Language, id string) string {
+	return l.Item(lang, "cards", id)
+}
+
+// ReadJSON decodes the file at path into v.
+func ReadJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// WriteJSON writes v to path as indented JSON, creating parent directories.
+// The file is replaced atomically so that an interrupted sync never leaves a
+// truncated file behind.
+func WriteJSON(path string, v any) error {
+	data, err := encodeJSON(v)
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(path, data)
+}
+
+func encodeJSON(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
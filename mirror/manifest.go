@@ -0,0 +1,79 @@
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/laiambryant/tcgdex/enums"
+)
+
+// ManifestVersion is the version of the manifest format written by this
+// package.
+const ManifestVersion = 1
+
+// ErrManifestVersion is returned by LoadManifest for a manifest written in a
+// format this package does not read.
+var ErrManifestVersion = errors.New("unsupported manifest version")
+
+// Manifest records what a mirror directory holds and when it was fetched.
+type Manifest struct {
+	Version   int                                  `json:"version"`
+	Languages map[enums.Language]*LanguageManifest `json:"languages"`
+}
+
+// LanguageManifest records the sync state of one language. CompletedAt is nil
+// while a sync is in progress; a later Sync resumes from the recorded items.
+// A zero time in Series marks a serie whose sets changed and that must be
+// fetched again.
+type LanguageManifest struct {
+	StartedAt   time.Time             `json:"startedAt"`
+	CompletedAt *time.Time            `json:"completedAt,omitempty"`
+	Series      map[string]time.Time  `json:"series"`
+	Sets        map[string]*SetRecord `json:"sets"`
+	Cards       map[string]time.Time  `json:"cards"`
+}
+
+// SetRecord describes a mirrored set. Complete is set once every card of the
+// set has been written.
+type SetRecord struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Serie     string    `json:"serie,omitempty"`
+	CardCount int       `json:"cardCount"`
+	Cards     []string  `json:"cards"`
+	Complete  bool      `json:"complete"`
+}
+
+func newLanguageManifest(now time.Time) *LanguageManifest {
+	return &LanguageManifest{
+		StartedAt: now,
+		Series:    make(map[string]time.Time),
+		Sets:      make(map[string]*SetRecord),
+		Cards:     make(map[string]time.Time),
+	}
+}
+
+// serieFetched reports whether the serie id is mirrored and up to date.
+func (lm *LanguageManifest) serieFetched(id string) bool {
+	fetchedAt, ok := lm.Series[id]
+	return ok && !fetchedAt.IsZero()
+}
+
+// LoadManifest reads the manifest of the mirror at l. A missing manifest
+// yields an empty one; a manifest of another version yields
+// ErrManifestVersion.
+func LoadManifest(l Layout) (*Manifest, error) {
+	m := &Manifest{}
+	if err := ReadJSON(l.Manifest(), m); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if m.Version != 0 && m.Version != ManifestVersion {
+		return nil, fmt.Errorf("%w %d in %s", ErrManifestVersion, m.Version, l.Manifest())
+	}
+	m.Version = ManifestVersion
+	if m.Languages == nil {
+		m.Languages = make(map[enums.Language]*LanguageManifest)
+	}
+	return m, nil
+}
@@ -0,0 +1,312 @@
+// Package mirror copies the TCGDex catalog to a local directory so that it can
+// be used without network access, e.g. through the offline package.
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/laiambryant/tcgdex"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+// DefaultConcurrency is the number of cards fetched in parallel by default.
+const DefaultConcurrency = 4
+
+// saveInterval is the number of cards written between two saves of the
+// manifest while a set is being mirrored.
+const saveInterval = 50
+
+// Mirror syncs the catalog of one or more languages to Layout.Dir.
+type Mirror struct {
+	SDK    *tcgdex.TCGDex
+	Layout Layout
+
+	concurrency int
+	saveEvery   int
+	now         func() time.Time
+
+	// run serializes Sync and Update, which share the manifest.
+	run      sync.Mutex
+	mu       sync.Mutex
+	manifest *Manifest
+	unsaved  int
+}
+
+type Option func(*Mirror)
+
+// WithConcurrency sets how many cards are fetched in parallel.
+func WithConcurrency(n int) Option {
+	return func(m *Mirror) {
+		m.concurrency = max(n, 1)
+	}
+}
+
+func New(sdk *tcgdex.TCGDex, dir string, opts ...Option) *Mirror {
+	m := &Mirror{
+		SDK:         sdk,
+		Layout:      Layout{Dir: dir},
+		concurrency: DefaultConcurrency,
+		saveEvery:   saveInterval,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// SyncError reports a resource that could not be mirrored.
+type SyncError struct {
+	Language enums.Language
+	Resource string
+	Err      error
+}
+
+func (e *SyncError) Error() string {
+	return fmt.Sprintf("sync %s %s: %v", e.Language, e.Resource, e.Err)
+}
+
+func (e *SyncError) Unwrap() error {
+	return e.Err
+}
+
+// Sync mirrors every serie, set and card of each language and records them in
+// the manifest. The manifest is saved as the sync progresses; when a previous
+// sync of a language was interrupted, the items it recorded are not fetched
+// again. A language whose last sync completed is mirrored from scratch.
+// Concurrent calls on one Mirror run one after the other.
+func (m *Mirror) Sync(ctx context.Context, langs ...enums.Language) error {
+	m.run.Lock()
+	defer m.run.Unlock()
+	manifest, err := m.loadManifest()
+	if err != nil {
+		return err
+	}
+	for _, lang := range langs {
+		lm := manifest.Languages[lang]
+		if lm == nil || lm.CompletedAt != nil {
+			lm = newLanguageManifest(m.now())
+			m.setLanguage(lang, lm)
+		}
+		if err := m.syncLanguage(ctx, lang, lm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Mirror) syncLanguage(ctx context.Context, lang enums.Language, lm *LanguageManifest) error {
+	sdk := m.SDK.ForLanguage(lang)
+
+	series, err := sdk.Serie.List(ctx, nil)
+	if err != nil {
+		return &SyncError{Language: lang, Resource: "series", Err: err}
+	}
+	if err := WriteJSON(m.Layout.List(lang, "series"), series); err != nil {
+		return err
+	}
+	for _, s := range series {
+		if lm.serieFetched(s.ID) {
+			continue
+		}
+		if err := m.syncSerie(ctx, sdk, lang, lm, s.ID); err != nil {
+			return err
+		}
+	}
+
+	sets, err := sdk.Set.List(ctx, nil)
+	if err != nil {
+		return &SyncError{Language: lang, Resource: "sets", Err: err}
+	}
+	if err := WriteJSON(m.Layout.List(lang, "sets"), sets); err != nil {
+		return err
+	}
+	for _, s := range sets {
+		if rec := lm.Sets[s.ID]; rec != nil && rec.Complete {
+			continue
+		}
+		if _, err := m.syncSet(ctx, sdk, lang, lm, s.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := m.writeCardList(lang, sets); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	now := m.now()
+	lm.CompletedAt = &now
+	m.mu.Unlock()
+	return m.saveManifest()
+}
+
+func (m *Mirror) syncSerie(ctx context.Context, sdk *tcgdex.TCGDex, lang enums.Language, lm *LanguageManifest, id string) error {
+	serie, err := sdk.Serie.Get(ctx, id)
+	if err != nil {
+		return &SyncError{Language: lang, Resource: "series/" + id, Err: err}
+	}
+	if err := WriteJSON(m.Layout.Serie(lang, id), serie); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	lm.Series[id] = m.now()
+	m.mu.Unlock()
+	return m.saveManifest()
+}
+
+// syncSet writes the set and every card of it not yet recorded in lm, then
+// marks the set complete.
+func (m *Mirror) syncSet(ctx context.Context, sdk *tcgdex.TCGDex, lang enums.Language, lm *LanguageManifest, id string) (models.Set, error) {
+	set, err := sdk.Set.Get(ctx, id)
+	if err != nil {
+		return set, &SyncError{Language: lang, Resource: "sets/" + id, Err: err}
+	}
+	if err := WriteJSON(m.Layout.Set(lang, id), set); err != nil {
+		return set, err
+	}
+
+	var pending []string
+	ids := make([]string, len(set.Cards))
+	m.mu.Lock()
+	for i, c := range set.Cards {
+		ids[i] = c.ID
+		if _, done := lm.Cards[c.ID]; !done {
+			pending = append(pending, c.ID)
+		}
+	}
+	lm.Sets[id] = &SetRecord{FetchedAt: m.now(), Serie: set.Serie.ID, CardCount: set.CardCount.Total, Cards: ids}
+	m.mu.Unlock()
+
+	err = m.fetchCards(ctx, sdk, lang, pending, func(card models.Card) error {
+		if err := WriteJSON(m.Layout.Card(lang, card.ID), card); err != nil {
+			return err
+		}
+		return m.recordCard(lm, card.ID)
+	})
+	if err != nil {
+		// Record the cards written so far so that a resumed sync skips them.
+		if serr := m.saveManifest(); serr != nil {
+			return set, serr
+		}
+		return set, err
+	}
+
+	m.mu.Lock()
+	lm.Sets[id].Complete = true
+	m.mu.Unlock()
+	return set, m.saveManifest()
+}
+
+// fetchCards fetches ids with bounded concurrency and hands each card to
+// store. It stops at the first error.
+func (m *Mirror) fetchCards(ctx context.Context, sdk *tcgdex.TCGDex, lang enums.Language, ids []string, store func(models.Card) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	sem := make(chan struct{}, m.concurrency)
+	for _, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			card, err := sdk.Card.Get(ctx, id)
+			if err != nil {
+				fail(&SyncError{Language: lang, Resource: "cards/" + id, Err: err})
+				return
+			}
+			if err := store(card); err != nil {
+				fail(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// writeCardList writes the card listing of lang from the cards of the
+// mirrored sets, in set order.
+func (m *Mirror) writeCardList(lang enums.Language, sets []models.SetResume) error {
+	cards := []models.CardResume{}
+	for _, s := range sets {
+		var set models.Set
+		if err := ReadJSON(m.Layout.Set(lang, s.ID), &set); err != nil {
+			return err
+		}
+		cards = append(cards, set.Cards...)
+	}
+	return WriteJSON(m.Layout.List(lang, "cards"), cards)
+}
+
+// recordCard marks a card as written and saves the manifest every saveEvery
+// cards, so that a sync killed in the middle of a large set resumes close to
+// where it stopped.
+func (m *Mirror) recordCard(lm *LanguageManifest, id string) error {
+	m.mu.Lock()
+	lm.Cards[id] = m.now()
+	m.unsaved++
+	save := m.unsaved >= m.saveEvery
+	m.mu.Unlock()
+	if save {
+		return m.saveManifest()
+	}
+	return nil
+}
+
+// loadManifest reads the manifest from disk and makes it the one Sync and
+// Update record their progress in.
+func (m *Mirror) loadManifest() (*Manifest, error) {
+	manifest, err := LoadManifest(m.Layout)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.manifest = manifest
+	m.unsaved = 0
+	return manifest, nil
+}
+
+func (m *Mirror) setLanguage(lang enums.Language, lm *LanguageManifest) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.manifest.Languages[lang] = lm
+}
+
+func (m *Mirror) saveManifest() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.unsaved = 0
+	return WriteJSON(m.Layout.Manifest(), m.manifest)
+}
+
+// Manifest returns the manifest of the last Sync, or nil before the first one.
+func (m *Mirror) Manifest() *Manifest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.manifest
+}
@@ -0,0 +1,216 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/laiambryant/tcgdex"
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+// fakeCatalog serves API paths such as /en/sets/base1 from routes and counts
+// the requests made to each path.
+type fakeCatalog struct {
+	mu        sync.Mutex
+	routes    map[string]string
+	fail      map[string]bool
+	calls     map[string]int
+	onRequest func(path string)
+}
+
+func (f *fakeCatalog) Do(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[req.URL.Path]++
+	if f.onRequest != nil {
+		f.onRequest(req.URL.Path)
+	}
+	if f.fail[req.URL.Path] {
+		return client.NewMockResponse(500, "boom"), nil
+	}
+	body, ok := f.routes[req.URL.Path]
+	if !ok {
+		return client.NewMockResponse(404, `{"error":"not found"}`), nil
+	}
+	return client.NewMockResponse(200, body), nil
+}
+
+func (f *fakeCatalog) resetCalls() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = make(map[string]int)
+}
+
+func newFakeCatalog() *fakeCatalog {
+	return &fakeCatalog{
+		routes: map[string]string{
+			"/en/series":      `[{"id":"base","name":"Base"}]`,
+			"/en/series/base": `{"id":"base","name":"Base","sets":[{"id":"base1","name":"Base Set","cardCount":{"total":2,"official":2}}]}`,
+			"/en/sets":        `[{"id":"base1","name":"Base Set","cardCount":{"total":2,"official":2}},{"id":"base2","name":"Jungle","cardCount":{"total":1,"official":1}}]`,
+			"/en/sets/base1": `{"id":"base1","name":"Base Set","cardCount":{"total":2,"official":2},"serie":{"id":"base","name":"Base"},` +
+				`"cards":[{"id":"base1-1","localId":"1","name":"Alakazam"},{"id":"base1-2","localId":"2","name":"Blastoise"}]}`,
+			"/en/sets/base2": `{"id":"base2","name":"Jungle","cardCount":{"total":1,"official":1},"serie":{"id":"base","name":"Base"},` +
+				`"cards":[{"id":"base2-1","localId":"1","name":"Clefable"}]}`,
+			"/en/cards/base1-1": `{"id":"base1-1","localId":"1","name":"Alakazam","hp":80}`,
+			"/en/cards/base1-2": `{"id":"base1-2","localId":"2","name":"Blastoise","hp":100}`,
+			"/en/cards/base2-1": `{"id":"base2-1","localId":"1","name":"Clefable","hp":70}`,
+			"/fr/series":        `[]`,
+			"/fr/sets":          `[]`,
+		},
+		fail:  map[string]bool{},
+		calls: map[string]int{},
+	}
+}
+
+func newTestMirror(t *testing.T, f *fakeCatalog) *Mirror {
+	t.Helper()
+	sdk := tcgdex.New(client.WithBaseURL("http://example/en"), client.WithHTTPClient(f))
+	return New(sdk, t.TempDir(), WithConcurrency(2))
+}
+
+func TestSyncWritesLayoutAndManifest(t *testing.T) {
+	f := newFakeCatalog()
+	m := newTestMirror(t, f)
+	if err := m.Sync(context.Background(), enums.LanguageEn, enums.LanguageFr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var card models.Card
+	if err := ReadJSON(m.Layout.Card(enums.LanguageEn, "base1-2"), &card); err != nil || card.Name != "Blastoise" {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+	var set models.Set
+	if err := ReadJSON(m.Layout.Set(enums.LanguageEn, "base2"), &set); err != nil || len(set.Cards) != 1 {
+		t.Fatalf("unexpected set %+v %v", set, err)
+	}
+	var serie models.Serie
+	if err := ReadJSON(m.Layout.Serie(enums.LanguageEn, "base"), &serie); err != nil || len(serie.Sets) != 1 {
+		t.Fatalf("unexpected serie %+v %v", serie, err)
+	}
+	var cards []models.CardResume
+	if err := ReadJSON(m.Layout.List(enums.LanguageEn, "cards"), &cards); err != nil || len(cards) != 3 || cards[2].ID != "base2-1" {
+		t.Fatalf("unexpected card list %+v %v", cards, err)
+	}
+	if _, err := os.Stat(m.Layout.List(enums.LanguageFr, "sets")); err != nil {
+		t.Fatalf("expected french set list: %v", err)
+	}
+
+	manifest, err := LoadManifest(m.Layout)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	en := manifest.Languages[enums.LanguageEn]
+	if en == nil || en.CompletedAt == nil || len(en.Cards) != 3 || len(en.Series) != 1 {
+		t.Fatalf("unexpected manifest %+v", en)
+	}
+	rec := en.Sets["base1"]
+	if rec == nil || !rec.Complete || rec.CardCount != 2 || len(rec.Cards) != 2 || rec.FetchedAt.IsZero() {
+		t.Fatalf("unexpected set record %+v", rec)
+	}
+	if manifest.Languages[enums.LanguageFr].CompletedAt == nil {
+		t.Fatal("expected french sync to be complete")
+	}
+}
+
+func TestSyncResumesInterruptedSync(t *testing.T) {
+	f := newFakeCatalog()
+	f.fail["/en/cards/base2-1"] = true
+	m := newTestMirror(t, f)
+
+	err := m.Sync(context.Background(), enums.LanguageEn)
+	var serr *SyncError
+	if !errors.As(err, &serr) || serr.Resource != "cards/base2-1" || serr.Language != enums.LanguageEn {
+		t.Fatalf("expected sync error for the failing card, got %v", err)
+	}
+	manifest, _ := LoadManifest(m.Layout)
+	en := manifest.Languages[enums.LanguageEn]
+	if en.CompletedAt != nil || !en.Sets["base1"].Complete || en.Sets["base2"].Complete {
+		t.Fatalf("unexpected manifest after interruption %+v", en)
+	}
+	started := en.StartedAt
+
+	f.fail = map[string]bool{}
+	f.resetCalls()
+	if err := m.Sync(context.Background(), enums.LanguageEn); err != nil {
+		t.Fatalf("unexpected error on resume: %v", err)
+	}
+	for _, path := range []string{"/en/series/base", "/en/sets/base1", "/en/cards/base1-1", "/en/cards/base1-2"} {
+		if f.calls[path] != 0 {
+			t.Fatalf("expected %s not to be fetched again", path)
+		}
+	}
+	if f.calls["/en/sets/base2"] != 1 || f.calls["/en/cards/base2-1"] != 1 {
+		t.Fatalf("expected the interrupted set to be fetched, got %v", f.calls)
+	}
+	manifest, _ = LoadManifest(m.Layout)
+	if en := manifest.Languages[enums.LanguageEn]; en.CompletedAt == nil || !en.StartedAt.Equal(started) {
+		t.Fatalf("expected resumed sync to complete, got %+v", en)
+	}
+
+	// A completed language is mirrored again from scratch.
+	f.resetCalls()
+	if err := m.Sync(context.Background(), enums.LanguageEn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.calls["/en/cards/base1-1"] != 1 {
+		t.Fatalf("expected full sync after completion, got %v", f.calls)
+	}
+}
+
+func TestSyncSavesProgressWithinSet(t *testing.T) {
+	f := newFakeCatalog()
+	m := newTestMirror(t, f)
+	m.concurrency = 1
+	m.saveEvery = 1
+
+	var recorded map[string]time.Time
+	f.onRequest = func(path string) {
+		if path == "/en/cards/base1-2" {
+			manifest, err := LoadManifest(m.Layout)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			recorded = manifest.Languages[enums.LanguageEn].Cards
+		}
+	}
+	if err := m.Sync(context.Background(), enums.LanguageEn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := recorded["base1-1"]; !ok {
+		t.Fatalf("expected base1-1 to be saved before the set completed, got %v", recorded)
+	}
+}
+
+func TestLoadManifestRejectsOtherVersions(t *testing.T) {
+	l := Layout{Dir: t.TempDir()}
+	if err := WriteJSON(l.Manifest(), Manifest{Version: ManifestVersion + 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := LoadManifest(l); !errors.Is(err, ErrManifestVersion) {
+		t.Fatalf("expected ErrManifestVersion, got %v", err)
+	}
+
+	m := New(nil, l.Dir)
+	if err := m.Sync(context.Background(), enums.LanguageEn); !errors.Is(err, ErrManifestVersion) {
+		t.Fatalf("expected Sync to refuse the manifest, got %v", err)
+	}
+}
+
+func TestLayoutEscapesIDs(t *testing.T) {
+	l := Layout{Dir: "root"}
+	if got, want := l.Card(enums.LanguageEn, "a/b?"), filepath.Join("root", "en", "cards", "a%2Fb%3F.json"); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := l.List(enums.LanguageFr, "sets"), filepath.Join("root", "fr", "sets.json"); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,300 @@
+package mirror
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/laiambryant/tcgdex"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/internal/atomicfile"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+// ErrNoSnapshot is returned by Update for a language that was never synced.
+var ErrNoSnapshot = errors.New("no snapshot to update")
+
+// CardChange identifies a card added, removed or modified by Update.
+type CardChange struct {
+	Language enums.Language `json:"language"`
+	Set      string         `json:"set"`
+	ID       string         `json:"id"`
+}
+
+// SetChange identifies a set fetched again or removed by Update.
+type SetChange struct {
+	Language enums.Language `json:"language"`
+	ID       string         `json:"id"`
+	Removed  bool           `json:"removed,omitempty"`
+}
+
+// ChangeReport lists the changes applied by Update, sorted by language, set
+// and card ID.
+type ChangeReport struct {
+	Added    []CardChange `json:"added"`
+	Removed  []CardChange `json:"removed"`
+	Modified []CardChange `json:"modified"`
+	Sets     []SetChange  `json:"sets"`
+}
+
+// Empty reports whether the update changed no card.
+func (r *ChangeReport) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Modified) == 0
+}
+
+// Update brings the snapshot of each language up to date without mirroring
+// it again. It compares the set listing with the manifest and only fetches
+// the sets that are new, whose card count changed or whose last sync did not
+// complete, along with their cards. Sets no longer listed are removed.
+//
+// A card is reported as modified when its JSON differs from the mirrored file.
+// Cards of sets that did not change are not fetched, so edits to them are not
+// detected; use Sync for a full refresh. Update does not run concurrently with
+// Sync or another Update on the same Mirror.
+func (m *Mirror) Update(ctx context.Context, langs ...enums.Language) (*ChangeReport, error) {
+	m.run.Lock()
+	defer m.run.Unlock()
+	manifest, err := m.loadManifest()
+	if err != nil {
+		return nil, err
+	}
+	report := &ChangeReport{}
+	for _, lang := range langs {
+		lm := manifest.Languages[lang]
+		if lm == nil {
+			return report, &SyncError{Language: lang, Resource: "manifest", Err: ErrNoSnapshot}
+		}
+		if err := m.updateLanguage(ctx, lang, lm, report); err != nil {
+			report.sort()
+			return report, err
+		}
+	}
+	report.sort()
+	return report, nil
+}
+
+func (m *Mirror) updateLanguage(ctx context.Context, lang enums.Language, lm *LanguageManifest, report *ChangeReport) error {
+	sdk := m.SDK.ForLanguage(lang)
+
+	series, err := sdk.Serie.List(ctx, nil)
+	if err != nil {
+		return &SyncError{Language: lang, Resource: "series", Err: err}
+	}
+	sets, err := sdk.Set.List(ctx, nil)
+	if err != nil {
+		return &SyncError{Language: lang, Resource: "sets", Err: err}
+	}
+
+	listed := make(map[string]bool, len(sets))
+	for _, s := range sets {
+		listed[s.ID] = true
+		rec := lm.Sets[s.ID]
+		if rec != nil && rec.Complete && rec.CardCount == s.CardCount.Total {
+			continue
+		}
+		if err := m.updateSet(ctx, sdk, lang, lm, s.ID, report); err != nil {
+			return err
+		}
+	}
+	for id, rec := range lm.Sets {
+		if listed[id] {
+			continue
+		}
+		if err := m.removeSet(lang, lm, id, rec, report); err != nil {
+			return err
+		}
+	}
+
+	if err := WriteJSON(m.Layout.List(lang, "series"), series); err != nil {
+		return err
+	}
+	listed = make(map[string]bool, len(series))
+	for _, s := range series {
+		listed[s.ID] = true
+		if lm.serieFetched(s.ID) {
+			continue
+		}
+		if err := m.syncSerie(ctx, sdk, lang, lm, s.ID); err != nil {
+			return err
+		}
+	}
+	for id := range lm.Series {
+		if listed[id] {
+			continue
+		}
+		if err := m.removeSerie(lang, lm, id); err != nil {
+			return err
+		}
+	}
+	if err := WriteJSON(m.Layout.List(lang, "sets"), sets); err != nil {
+		return err
+	}
+	if err := m.writeCardList(lang, sets); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	now := m.now()
+	lm.CompletedAt = &now
+	m.mu.Unlock()
+	return m.saveManifest()
+}
+
+// updateSet fetches the set and all of its cards, recording in report the
+// cards that appeared, disappeared or changed since the last sync, and marks
+// the serie of the set stale.
+func (m *Mirror) updateSet(ctx context.Context, sdk *tcgdex.TCGDex, lang enums.Language, lm *LanguageManifest, id string, report *ChangeReport) error {
+	set, err := sdk.Set.Get(ctx, id)
+	if err != nil {
+		return &SyncError{Language: lang, Resource: "sets/" + id, Err: err}
+	}
+	report.Sets = append(report.Sets, SetChange{Language: lang, ID: id})
+
+	ids := make([]string, len(set.Cards))
+	for i, c := range set.Cards {
+		ids[i] = c.ID
+	}
+	m.mu.Lock()
+	rec := lm.Sets[id]
+	var removed []string
+	if rec != nil {
+		rec.Complete = false
+		for _, cardID := range rec.Cards {
+			if !slices.Contains(ids, cardID) {
+				removed = append(removed, cardID)
+			}
+		}
+	}
+	m.mu.Unlock()
+	if len(removed) > 0 {
+		for _, cardID := range removed {
+			if err := m.removeCard(lang, lm, cardID); err != nil {
+				return err
+			}
+		}
+		// Save the removal before reporting it, so that a retried Update
+		// does not report the same cards again.
+		m.mu.Lock()
+		rec.Cards = slices.DeleteFunc(slices.Clone(rec.Cards), func(c string) bool {
+			return slices.Contains(removed, c)
+		})
+		m.mu.Unlock()
+		if err := m.saveManifest(); err != nil {
+			return err
+		}
+		for _, cardID := range removed {
+			report.Removed = append(report.Removed, CardChange{Language: lang, Set: id, ID: cardID})
+		}
+	}
+
+	err = m.fetchCards(ctx, sdk, lang, ids, func(card models.Card) error {
+		data, err := encodeJSON(card)
+		if err != nil {
+			return err
+		}
+		path := m.Layout.Card(lang, card.ID)
+		old, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		change := CardChange{Language: lang, Set: id, ID: card.ID}
+		m.mu.Lock()
+		switch {
+		case old == nil:
+			report.Added = append(report.Added, change)
+		case !bytes.Equal(old, data):
+			report.Modified = append(report.Modified, change)
+		}
+		m.mu.Unlock()
+		if err := atomicfile.Write(path, data); err != nil {
+			return err
+		}
+		return m.recordCard(lm, card.ID)
+	})
+	if err != nil {
+		if serr := m.saveManifest(); serr != nil {
+			return serr
+		}
+		return err
+	}
+
+	if err := WriteJSON(m.Layout.Set(lang, id), set); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	lm.Sets[id] = &SetRecord{FetchedAt: m.now(), Serie: set.Serie.ID, CardCount: set.CardCount.Total, Cards: ids, Complete: true}
+	markSerieStale(lm, set.Serie.ID)
+	m.mu.Unlock()
+	return m.saveManifest()
+}
+
+// removeSet deletes a set that is no longer listed, along with its cards, and
+// marks its serie stale. The removal is saved before it is reported, so that
+// a retried Update does not report it again.
+func (m *Mirror) removeSet(lang enums.Language, lm *LanguageManifest, id string, rec *SetRecord, report *ChangeReport) error {
+	for _, cardID := range rec.Cards {
+		if err := m.removeCard(lang, lm, cardID); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(m.Layout.Set(lang, id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	m.mu.Lock()
+	delete(lm.Sets, id)
+	markSerieStale(lm, rec.Serie)
+	m.mu.Unlock()
+	if err := m.saveManifest(); err != nil {
+		return err
+	}
+	for _, cardID := range rec.Cards {
+		report.Removed = append(report.Removed, CardChange{Language: lang, Set: id, ID: cardID})
+	}
+	report.Sets = append(report.Sets, SetChange{Language: lang, ID: id, Removed: true})
+	return nil
+}
+
+// removeSerie deletes a serie that is no longer listed.
+func (m *Mirror) removeSerie(lang enums.Language, lm *LanguageManifest, id string) error {
+	if err := os.Remove(m.Layout.Serie(lang, id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	m.mu.Lock()
+	delete(lm.Series, id)
+	m.mu.Unlock()
+	return m.saveManifest()
+}
+
+// markSerieStale records that the serie id must be fetched again. The caller
+// holds m.mu.
+func markSerieStale(lm *LanguageManifest, id string) {
+	if _, ok := lm.Series[id]; ok {
+		lm.Series[id] = time.Time{}
+	}
+}
+
+func (m *Mirror) removeCard(lang enums.Language, lm *LanguageManifest, id string) error {
+	if err := os.Remove(m.Layout.Card(lang, id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	m.mu.Lock()
+	delete(lm.Cards, id)
+	m.mu.Unlock()
+	return nil
+}
+
+func (r *ChangeReport) sort() {
+	byCard := func(a, b CardChange) int {
+		return cmp.Or(cmp.Compare(a.Language, b.Language), cmp.Compare(a.Set, b.Set), cmp.Compare(a.ID, b.ID))
+	}
+	slices.SortFunc(r.Added, byCard)
+	slices.SortFunc(r.Removed, byCard)
+	slices.SortFunc(r.Modified, byCard)
+	slices.SortFunc(r.Sets, func(a, b SetChange) int {
+		return cmp.Or(cmp.Compare(a.Language, b.Language), cmp.Compare(a.ID, b.ID))
+	})
+}
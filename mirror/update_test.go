@@ -0,0 +1,171 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/models"
+)
+
+func TestUpdateFetchesOnlyChangedSets(t *testing.T) {
+	f := newFakeCatalog()
+	m := newTestMirror(t, f)
+	ctx := context.Background()
+	if err := m.Sync(ctx, enums.LanguageEn); err != nil {
+		t.Fatalf("unexpected sync error: %v", err)
+	}
+
+	f.resetCalls()
+	report, err := m.Update(ctx, enums.LanguageEn)
+	if err != nil || !report.Empty() || len(report.Sets) != 0 {
+		t.Fatalf("expected no changes, got %+v %v", report, err)
+	}
+	if f.calls["/en/sets/base1"] != 0 || f.calls["/en/cards/base1-1"] != 0 {
+		t.Fatalf("expected unchanged sets not to be fetched, got %v", f.calls)
+	}
+
+	f.routes["/en/sets"] = `[{"id":"base1","name":"Base Set","cardCount":{"total":3,"official":3}},` +
+		`{"id":"base3","name":"Fossil","cardCount":{"total":1,"official":1}}]`
+	f.routes["/en/sets/base1"] = `{"id":"base1","name":"Base Set","cardCount":{"total":3,"official":3},"serie":{"id":"base","name":"Base"},` +
+		`"cards":[{"id":"base1-1","localId":"1","name":"Alakazam"},{"id":"base1-2","localId":"2","name":"Blastoise"},{"id":"base1-3","localId":"3","name":"Chansey"}]}`
+	f.routes["/en/sets/base3"] = `{"id":"base3","name":"Fossil","cardCount":{"total":1,"official":1},"serie":{"id":"base","name":"Base"},` +
+		`"cards":[{"id":"base3-1","localId":"1","name":"Aerodactyl"}]}`
+	f.routes["/en/cards/base1-2"] = `{"id":"base1-2","localId":"2","name":"Blastoise","hp":110}`
+	f.routes["/en/cards/base1-3"] = `{"id":"base1-3","localId":"3","name":"Chansey","hp":120}`
+	f.routes["/en/cards/base3-1"] = `{"id":"base3-1","localId":"1","name":"Aerodactyl","hp":60}`
+	delete(f.routes, "/en/sets/base2")
+
+	f.resetCalls()
+	report, err = m.Update(ctx, enums.LanguageEn)
+	if err != nil {
+		t.Fatalf("unexpected update error: %v", err)
+	}
+	card := func(set, id string) CardChange { return CardChange{Language: enums.LanguageEn, Set: set, ID: id} }
+	want := &ChangeReport{
+		Added:    []CardChange{card("base1", "base1-3"), card("base3", "base3-1")},
+		Removed:  []CardChange{card("base2", "base2-1")},
+		Modified: []CardChange{card("base1", "base1-2")},
+		Sets: []SetChange{
+			{Language: enums.LanguageEn, ID: "base1"},
+			{Language: enums.LanguageEn, ID: "base2", Removed: true},
+			{Language: enums.LanguageEn, ID: "base3"},
+		},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Fatalf("unexpected report\n got: %+v\nwant: %+v", report, want)
+	}
+	if f.calls["/en/series/base"] != 1 {
+		t.Fatalf("expected the serie of changed sets to be refreshed, got %v", f.calls)
+	}
+
+	if _, err := os.Stat(m.Layout.Card(enums.LanguageEn, "base2-1")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected removed card file to be deleted, got %v", err)
+	}
+	var updated models.Card
+	if err := ReadJSON(m.Layout.Card(enums.LanguageEn, "base1-2"), &updated); err != nil || updated.HP == nil || *updated.HP != 110 {
+		t.Fatalf("unexpected updated card %+v %v", updated, err)
+	}
+	var cards []models.CardResume
+	if err := ReadJSON(m.Layout.List(enums.LanguageEn, "cards"), &cards); err != nil || len(cards) != 4 {
+		t.Fatalf("unexpected card list %+v %v", cards, err)
+	}
+	manifest, _ := LoadManifest(m.Layout)
+	en := manifest.Languages[enums.LanguageEn]
+	if en.Sets["base2"] != nil || en.Sets["base1"].CardCount != 3 || len(en.Cards) != 4 {
+		t.Fatalf("unexpected manifest %+v", en)
+	}
+}
+
+func TestUpdateWithoutSnapshot(t *testing.T) {
+	m := newTestMirror(t, newFakeCatalog())
+	_, err := m.Update(context.Background(), enums.LanguageFr)
+	var serr *SyncError
+	if !errors.Is(err, ErrNoSnapshot) || !errors.As(err, &serr) || serr.Language != enums.LanguageFr {
+		t.Fatalf("expected ErrNoSnapshot, got %v", err)
+	}
+}
+
+func TestUpdateRemovesSetFromUnchangedSerie(t *testing.T) {
+	f := newFakeCatalog()
+	f.routes["/en/series"] = `[{"id":"base","name":"Base"},{"id":"gym","name":"Gym"}]`
+	f.routes["/en/series/base"] = `{"id":"base","name":"Base","sets":[{"id":"base1","name":"Base Set","cardCount":{"total":2,"official":2}},` +
+		`{"id":"base2","name":"Jungle","cardCount":{"total":1,"official":1}}]}`
+	f.routes["/en/series/gym"] = `{"id":"gym","name":"Gym","sets":[]}`
+	m := newTestMirror(t, f)
+	ctx := context.Background()
+	if err := m.Sync(ctx, enums.LanguageEn); err != nil {
+		t.Fatalf("unexpected sync error: %v", err)
+	}
+
+	// base2 and the gym serie disappear; base1 is unchanged. Fetching the
+	// serie fails once, so the removal must survive a retried Update.
+	f.routes["/en/series"] = `[{"id":"base","name":"Base"}]`
+	f.routes["/en/series/base"] = `{"id":"base","name":"Base","sets":[{"id":"base1","name":"Base Set","cardCount":{"total":2,"official":2}}]}`
+	f.routes["/en/sets"] = `[{"id":"base1","name":"Base Set","cardCount":{"total":2,"official":2}}]`
+	f.fail["/en/series/base"] = true
+
+	report, err := m.Update(ctx, enums.LanguageEn)
+	var serr *SyncError
+	if !errors.As(err, &serr) || serr.Resource != "series/base" {
+		t.Fatalf("expected the serie to fail, got %v", err)
+	}
+	want := &ChangeReport{
+		Removed: []CardChange{{Language: enums.LanguageEn, Set: "base2", ID: "base2-1"}},
+		Sets:    []SetChange{{Language: enums.LanguageEn, ID: "base2", Removed: true}},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Fatalf("unexpected report\n got: %+v\nwant: %+v", report, want)
+	}
+
+	f.fail = map[string]bool{}
+	f.resetCalls()
+	report, err = m.Update(ctx, enums.LanguageEn)
+	if err != nil || !report.Empty() || len(report.Sets) != 0 {
+		t.Fatalf("expected the removal not to be reported again, got %+v %v", report, err)
+	}
+	if f.calls["/en/series/base"] != 1 || f.calls["/en/sets/base1"] != 0 {
+		t.Fatalf("expected only the stale serie to be fetched, got %v", f.calls)
+	}
+
+	var serie models.Serie
+	if err := ReadJSON(m.Layout.Serie(enums.LanguageEn, "base"), &serie); err != nil || len(serie.Sets) != 1 {
+		t.Fatalf("expected the serie to list only base1, got %+v %v", serie, err)
+	}
+	if _, err := os.Stat(m.Layout.Serie(enums.LanguageEn, "gym")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the unlisted serie to be deleted, got %v", err)
+	}
+	manifest, _ := LoadManifest(m.Layout)
+	en := manifest.Languages[enums.LanguageEn]
+	if _, ok := en.Series["gym"]; ok || en.Series["base"].IsZero() || en.Sets["base2"] != nil || len(en.Cards) != 2 {
+		t.Fatalf("unexpected manifest %+v", en)
+	}
+}
+
+func TestUpdateDoesNotReportRemovedCardsTwice(t *testing.T) {
+	f := newFakeCatalog()
+	m := newTestMirror(t, f)
+	ctx := context.Background()
+	if err := m.Sync(ctx, enums.LanguageEn); err != nil {
+		t.Fatalf("unexpected sync error: %v", err)
+	}
+
+	f.routes["/en/sets"] = `[{"id":"base1","name":"Base Set","cardCount":{"total":1,"official":1}},` +
+		`{"id":"base2","name":"Jungle","cardCount":{"total":1,"official":1}}]`
+	f.routes["/en/sets/base1"] = `{"id":"base1","name":"Base Set","cardCount":{"total":1,"official":1},"serie":{"id":"base","name":"Base"},` +
+		`"cards":[{"id":"base1-1","localId":"1","name":"Alakazam"}]}`
+	f.fail["/en/cards/base1-1"] = true
+	report, err := m.Update(ctx, enums.LanguageEn)
+	if err == nil || len(report.Removed) != 1 || report.Removed[0].ID != "base1-2" {
+		t.Fatalf("expected base1-2 to be removed before the failure, got %+v %v", report, err)
+	}
+
+	f.fail = map[string]bool{}
+	report, err = m.Update(ctx, enums.LanguageEn)
+	if err != nil || len(report.Removed) != 0 {
+		t.Fatalf("expected the removal not to be reported again, got %+v %v", report, err)
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import "github.com/laiambryant/tcgdex/enums"
+
+// LocalizedCard merges the translations of a single card fetched from several
+// locales of the API.
+type LocalizedCard struct {
+	ID    string
+	Cards map[enums.Language]Card
+	// Missing lists the requested languages in which the card does not exist.
+	Missing []enums.Language
+}
+
+// Names returns the card name for every language it was found in.
+func (l *LocalizedCard) Names() map[enums.Language]string {
+	names := make(map[enums.Language]string, len(l.Cards))
+	for lang, card := range l.Cards {
+		names[lang] = card.Name
+	}
+	return names
+}
+
+// Attacks returns the attacks of the card in the given language, or nil when
+// the card was not found in that language.
+func (l *LocalizedCard) Attacks(lang enums.Language) []CardAttack {
+	card, ok := l.Cards[lang]
+	if !ok {
+		return nil
+	}
+	return card.Attacks
+}
+
+// AttackNames returns, for every language, the attack names in card order.
+func (l *LocalizedCard) AttackNames() map[enums.Language][]string {
+	names := make(map[enums.Language][]string, len(l.Cards))
+	for lang, card := range l.Cards {
+		for _, attack := range card.Attacks {
+			name := ""
+			if attack.Name != nil {
+				name = *attack.Name
+			}
+			names[lang] = append(names[lang], name)
+		}
+	}
+	return names
+}
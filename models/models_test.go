@@ -24,6 +24,30 @@ func TestCardResume_GetImageURL(t *testing.T) {
 	}
 }
 
+func TestLogoAndSymbolURLs(t *testing.T) {
+	var s SetResume
+	if s.GetLogoURL(enums.ExtensionPng) != nil || s.GetSymbolURL(enums.ExtensionPng) != nil {
+		t.Fatalf("expected nil URLs for a set without assets")
+	}
+	logo, symbol := "https://assets.tcgdex.net/en/base/base1/logo", "https://assets.tcgdex.net/univ/base/base1/symbol"
+	s.Logo, s.Symbol = &logo, &symbol
+	if got := s.GetLogoURL(enums.ExtensionWebp); got == nil || *got != logo+".webp" {
+		t.Fatalf("unexpected logo URL %v", got)
+	}
+	if got := s.GetSymbolURL(enums.ExtensionPng); got == nil || *got != symbol+".png" {
+		t.Fatalf("unexpected symbol URL %v", got)
+	}
+
+	var serie SerieResume
+	if serie.GetLogoURL(enums.ExtensionJpg) != nil {
+		t.Fatalf("expected nil URL for a serie without logo")
+	}
+	serie.Logo = &logo
+	if got := serie.GetLogoURL(enums.ExtensionJpg); got == nil || *got != logo+".jpg" {
+		t.Fatalf("unexpected serie logo URL %v", got)
+	}
+}
+
 func TestDamage_UnmarshalJSON(t *testing.T) {
 	var d Damage
 	if err := json.Unmarshal([]byte("10"), &d); err != nil {
@@ -42,3 +66,41 @@ func TestDamage_UnmarshalJSON(t *testing.T) {
 		t.Fatalf("expected error unmarshaling invalid damage, got nil")
 	}
 }
+
+func TestLocalizedCard(t *testing.T) {
+	fire := "Fire Spin"
+	lc := LocalizedCard{
+		ID: "base1-4",
+		Cards: map[enums.Language]Card{
+			enums.LanguageEn: {CardResume: CardResume{Name: "Charizard"}, Attacks: []CardAttack{{Name: &fire}, {}}},
+		},
+	}
+	if got := lc.Names()[enums.LanguageEn]; got != "Charizard" {
+		t.Fatalf("unexpected name: %s", got)
+	}
+	if got := lc.AttackNames()[enums.LanguageEn]; len(got) != 2 || got[0] != fire || got[1] != "" {
+		t.Fatalf("unexpected attack names: %v", got)
+	}
+	if lc.Attacks(enums.LanguageFr) != nil || len(lc.Attacks(enums.LanguageEn)) != 2 {
+		t.Fatalf("unexpected attacks lookup")
+	}
+}
+
+func TestStringEndpoint_UnmarshalJSON(t *testing.T) {
+	var s StringEndpoint
+	if err := json.Unmarshal([]byte(`{"name":"Fire","cards":[{"id":"a"}]}`), &s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Name != "Fire" || len(s.Cards) != 1 {
+		t.Fatalf("unexpected value %+v", s)
+	}
+	if err := json.Unmarshal([]byte(`{"name":2.5}`), &s); err != nil || s.Name != "2.5" {
+		t.Fatalf("unexpected numeric name %q %v", s.Name, err)
+	}
+	if err := json.Unmarshal([]byte(`{"name":[1]}`), &s); err == nil {
+		t.Fatalf("expected error for invalid name")
+	}
+	if err := json.Unmarshal([]byte(`[]`), &s); err == nil {
+		t.Fatalf("expected error for invalid payload")
+	}
+}
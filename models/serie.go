@@ -2,5 +2,6 @@ package models
 
 type Serie struct {
 	SerieResume
-	Sets []SetResume `json:"sets"`
+	ReleaseDate string      `json:"releaseDate,omitempty"`
+	Sets        []SetResume `json:"sets"`
 }
@@ -1,7 +1,15 @@
 package models
 
+import "github.com/laiambryant/tcgdex/enums"
+
 type SerieResume struct {
 	ID   string  `json:"id"`
 	Name string  `json:"name"`
 	Logo *string `json:"logo,omitempty"`
 }
+
+// GetLogoURL returns the URL of the serie logo in the given format, or nil
+// when the serie has no logo.
+func (s *SerieResume) GetLogoURL(extension enums.Extension) *string {
+	return assetURL(s.Logo, extension)
+}
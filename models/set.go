@@ -2,6 +2,7 @@ package models
 
 type Set struct {
 	SetResume
-	Serie SerieResume  `json:"serie"`
-	Cards []CardResume `json:"cards"`
+	Serie       SerieResume  `json:"serie"`
+	ReleaseDate string       `json:"releaseDate,omitempty"`
+	Cards       []CardResume `json:"cards"`
 }
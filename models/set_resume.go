@@ -1,5 +1,11 @@
 package models
 
+import (
+	"fmt"
+
+	"github.com/laiambryant/tcgdex/enums"
+)
+
 type SetResume struct {
 	ID        string       `json:"id"`
 	Name      string       `json:"name"`
@@ -7,3 +13,24 @@ type SetResume struct {
 	Symbol    *string      `json:"symbol,omitempty"`
 	CardCount SetCardCount `json:"cardCount"`
 }
+
+// GetLogoURL returns the URL of the set logo in the given format, or nil when
+// the set has no logo.
+func (s *SetResume) GetLogoURL(extension enums.Extension) *string {
+	return assetURL(s.Logo, extension)
+}
+
+// GetSymbolURL returns the URL of the set symbol in the given format, or nil
+// when the set has no symbol.
+func (s *SetResume) GetSymbolURL(extension enums.Extension) *string {
+	return assetURL(s.Symbol, extension)
+}
+
+// assetURL appends the file extension to the base URL of a logo or symbol.
+func assetURL(base *string, extension enums.Extension) *string {
+	if base == nil {
+		return nil
+	}
+	url := fmt.Sprintf("%s.%s", *base, extension)
+	return &url
+}
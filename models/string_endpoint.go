@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// StringEndpoint is the detail of a secondary resource such as a type, a
+// rarity or an illustrator: the value itself and every card that has it.
+type StringEndpoint struct {
+	Name  string       `json:"name"`
+	Cards []CardResume `json:"cards"`
+}
+
+// UnmarshalJSON accepts numeric names, as returned by resources like hp or
+// retreats, and stores them in their decimal form.
+func (s *StringEndpoint) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name  json.RawMessage `json:"name"`
+		Cards []CardResume    `json:"cards"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	name, err := ValueString(raw.Name)
+	if err != nil {
+		return err
+	}
+	s.Name = name
+	s.Cards = raw.Cards
+	return nil
+}
+
+// ValueString decodes a JSON string or number into its string form.
+func ValueString(data json.RawMessage) (string, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" || trimmed == "null" {
+		return "", nil
+	}
+	if strings.HasPrefix(trimmed, `"`) {
+		var s string
+		err := json.Unmarshal(data, &s)
+		return s, err
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
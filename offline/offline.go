@@ -0,0 +1,258 @@
+// Package offline serves a snapshot written by the mirror package as if it
+// were the TCGDex API, so that the SDK works without network access.
+package offline
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/mirror"
+	"github.com/laiambryant/tcgdex/query"
+)
+
+// resources are the API resources found in a snapshot.
+var resources = []string{"cards", "sets", "series"}
+
+// Handler answers API requests from a snapshot directory. It serves the
+// listings of cards, sets and series with filtering, sorting and pagination,
+// single items by ID, cards by set and local ID, and the random routes.
+// Anything else, including the secondary resources such as /types, is
+// reported as not found.
+//
+// Paths are matched on the segments following the language, so any base URL
+// works, e.g. https://api.tcgdex.net/v2/en or http://localhost/en.
+type Handler struct {
+	Layout mirror.Layout
+}
+
+func New(dir string) *Handler {
+	return &Handler{Layout: mirror.Layout{Dir: dir}}
+}
+
+// WithSnapshot configures a client to read from the snapshot in dir instead
+// of the network.
+func WithSnapshot(dir string) client.Option {
+	return client.WithHTTPClient(New(dir))
+}
+
+// Do serves req in process, which makes Handler usable as a client.HTTPClient.
+func (h *Handler) Do(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	w := &responseWriter{header: make(http.Header)}
+	h.ServeHTTP(w, req)
+	return w.response(req), nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	lang, segments, ok := splitPath(r.URL)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && slices.Contains(resources, segments[0]):
+		h.serveList(w, r, lang, segments[0])
+	case len(segments) == 2 && slices.Contains(resources, segments[0]):
+		h.serveFile(w, h.Layout.Item(lang, segments[0], segments[1]))
+	case len(segments) == 3 && segments[0] == "sets":
+		h.serveSetCard(w, lang, segments[1], segments[2])
+	case len(segments) == 2 && segments[0] == "random":
+		h.serveRandom(w, lang, segments[1])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// splitPath finds the language segment of u and returns it with the decoded
+// segments that follow it.
+func splitPath(u *url.URL) (enums.Language, []string, bool) {
+	parts := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if !slices.Contains(resources, parts[i+1]) && parts[i+1] != "random" {
+			continue
+		}
+		segments := make([]string, 0, len(parts)-i-1)
+		for _, p := range parts[i+1:] {
+			s, err := url.PathUnescape(p)
+			if err != nil {
+				return "", nil, false
+			}
+			segments = append(segments, s)
+		}
+		if parts[i] == "" || parts[i] == "." || parts[i] == ".." {
+			return "", nil, false
+		}
+		return enums.Language(parts[i]), segments, true
+	}
+	return "", nil, false
+}
+
+func (h *Handler) serveList(w http.ResponseWriter, r *http.Request, lang enums.Language, resource string) {
+	var items []json.RawMessage
+	if !h.read(w, h.Layout.List(lang, resource), &items) {
+		return
+	}
+	q, err := query.Parse(r.URL.RawQuery)
+	if err == nil {
+		err = q.Validate()
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	items, err = h.apply(lang, resource, items, q)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	writeJSON(w, items)
+}
+
+// apply evaluates q the way the API does: filters and sorting see every field
+// of the full items, while the listing entries are returned. Items whose file
+// is missing from the snapshot are evaluated on their listing entry.
+func (h *Handler) apply(lang enums.Language, resource string, items []json.RawMessage, q *query.Query) ([]json.RawMessage, error) {
+	if _, _, sorted := q.SortBy(); len(q.Filters()) == 0 && !sorted {
+		return query.Apply(items, q)
+	}
+	full := make([]json.RawMessage, len(items))
+	byID := make(map[string]json.RawMessage, len(items))
+	for i, item := range items {
+		id, err := itemID(item)
+		if err != nil {
+			return nil, err
+		}
+		byID[id] = item
+		data, err := os.ReadFile(h.Layout.Item(lang, resource, id))
+		switch {
+		case err == nil:
+			full[i] = data
+		case errors.Is(err, fs.ErrNotExist):
+			full[i] = item
+		default:
+			return nil, err
+		}
+	}
+	matched, err := query.Apply(full, q)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]json.RawMessage, len(matched))
+	for i, m := range matched {
+		id, err := itemID(m)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = byID[id]
+	}
+	return out, nil
+}
+
+func itemID(item json.RawMessage) (string, error) {
+	var ref struct {
+		ID string `json:"id"`
+	}
+	err := json.Unmarshal(item, &ref)
+	return ref.ID, err
+}
+
+func (h *Handler) serveSetCard(w http.ResponseWriter, lang enums.Language, setID, localID string) {
+	var set struct {
+		Cards []struct {
+			ID      string `json:"id"`
+			LocalID string `json:"localId"`
+		} `json:"cards"`
+	}
+	if !h.read(w, h.Layout.Set(lang, setID), &set) {
+		return
+	}
+	for _, c := range set.Cards {
+		if c.LocalID == localID {
+			h.serveFile(w, h.Layout.Card(lang, c.ID))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+// serveRandom serves a random item of resource, named in the singular as in
+// the API's /random/card route.
+func (h *Handler) serveRandom(w http.ResponseWriter, lang enums.Language, name string) {
+	resource := name + "s"
+	if name == "serie" {
+		resource = "series"
+	}
+	if !slices.Contains(resources, resource) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	var items []struct {
+		ID string `json:"id"`
+	}
+	if !h.read(w, h.Layout.List(lang, resource), &items) {
+		return
+	}
+	if len(items) == 0 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	h.serveFile(w, h.Layout.Item(lang, resource, items[rand.IntN(len(items))].ID))
+}
+
+func (h *Handler) serveFile(w http.ResponseWriter, path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// read decodes the snapshot file at path into v, answering the request with
+// an error when it cannot.
+func (h *Handler) read(w http.ResponseWriter, path string, v any) bool {
+	if err := mirror.ReadJSON(path, v); err != nil {
+		writeFileError(w, err)
+		return false
+	}
+	return true
+}
+
+func writeFileError(w http.ResponseWriter, err error) {
+	if errors.Is(err, fs.ErrNotExist) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,170 @@
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/laiambryant/tcgdex"
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/mirror"
+	"github.com/laiambryant/tcgdex/models"
+	"github.com/laiambryant/tcgdex/query"
+)
+
+func intPtr(n int) *int { return &n }
+
+// writeSnapshot writes a small snapshot in the layout of the mirror package.
+func writeSnapshot(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	l := mirror.Layout{Dir: dir}
+	en := enums.LanguageEn
+	cards := []models.Card{
+		{CardResume: models.CardResume{ID: "base1-4", LocalID: "4", Name: "Charizard"}, HP: intPtr(120), Types: []string{"Fire"}},
+		{CardResume: models.CardResume{ID: "base1-58", LocalID: "58", Name: "Pikachu"}, HP: intPtr(40), Types: []string{"Lightning"}},
+		{CardResume: models.CardResume{ID: "jungle-60", LocalID: "60", Name: "Pikachu"}, HP: intPtr(50), Types: []string{"Lightning"}},
+	}
+	var resumes []models.CardResume
+	for _, c := range cards {
+		resumes = append(resumes, c.CardResume)
+		must(t, mirror.WriteJSON(l.Card(en, c.ID), c))
+	}
+	base1 := models.Set{
+		SetResume: models.SetResume{ID: "base1", Name: "Base Set", CardCount: models.SetCardCount{Total: 2, Official: 2}},
+		Serie:     models.SerieResume{ID: "base", Name: "Base"},
+		Cards:     resumes[:2],
+	}
+	serie := models.Serie{SerieResume: base1.Serie, Sets: []models.SetResume{base1.SetResume}}
+	must(t, mirror.WriteJSON(l.Set(en, "base1"), base1))
+	must(t, mirror.WriteJSON(l.Serie(en, "base"), serie))
+	must(t, mirror.WriteJSON(l.List(en, "cards"), resumes))
+	must(t, mirror.WriteJSON(l.List(en, "sets"), []models.SetResume{base1.SetResume}))
+	must(t, mirror.WriteJSON(l.List(en, "series"), []models.SerieResume{serie.SerieResume}))
+	return dir
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSDKAgainstSnapshot(t *testing.T) {
+	sdk := tcgdex.New(WithSnapshot(writeSnapshot(t)))
+	ctx := context.Background()
+
+	card, err := sdk.Card.Get(ctx, "base1-4")
+	if err != nil || card.Name != "Charizard" || card.HP == nil || *card.HP != 120 {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+
+	list, err := sdk.Card.List(ctx, query.New().Equal("name", "Pikachu").Sort("hp", "DESC"))
+	if err != nil || len(list) != 2 || list[0].ID != "jungle-60" {
+		t.Fatalf("unexpected filtered list %+v %v", list, err)
+	}
+	list, err = sdk.Card.List(ctx, query.New().Paginate(2, 2))
+	if err != nil || len(list) != 1 || list[0].ID != "jungle-60" {
+		t.Fatalf("unexpected page %+v %v", list, err)
+	}
+	list, err = sdk.Card.List(ctx, query.New().Contains("name", "mew"))
+	if err != nil || list == nil || len(list) != 0 {
+		t.Fatalf("expected an empty list, got %+v %v", list, err)
+	}
+
+	byNumber, err := sdk.GetSetCard(ctx, "base1", "58")
+	if err != nil || byNumber.ID != "base1-58" {
+		t.Fatalf("unexpected card by number %+v %v", byNumber, err)
+	}
+	series, err := sdk.Serie.List(ctx, nil)
+	if err != nil || len(series) != 1 || series[0].ID != "base" {
+		t.Fatalf("unexpected series %+v %v", series, err)
+	}
+	set, err := sdk.Set.Random(ctx)
+	if err != nil || set.ID != "base1" {
+		t.Fatalf("unexpected random set %+v %v", set, err)
+	}
+
+	if _, err := sdk.Card.Get(ctx, "base1-999"); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing card, got %v", err)
+	}
+	if _, err := sdk.ForLanguage(enums.LanguageFr).Card.Get(ctx, "base1-4"); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a language not in the snapshot, got %v", err)
+	}
+	if _, err := sdk.Type.List(ctx); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for secondary resources, got %v", err)
+	}
+
+	_, err = sdk.Client.Get(ctx, "/cards?sort:order=sideways")
+	var he *client.HTTPError
+	if !errors.As(err, &he) || he.Status != http.StatusBadRequest {
+		t.Fatalf("expected bad request for an invalid query, got %v", err)
+	}
+}
+
+func TestDoBuildsResponses(t *testing.T) {
+	h := New(writeSnapshot(t))
+	req, _ := http.NewRequest(http.MethodGet, "http://example/v2/en/cards/base1-4", nil)
+	resp, err := h.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json" || resp.ContentLength != int64(len(body)) {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	var card models.Card
+	if err := json.Unmarshal(body, &card); err != nil || card.Name != "Charizard" {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "http://example/v2/en/cards/unknown", nil)
+	resp, err = h.Do(req)
+	if err != nil || resp.StatusCode != http.StatusNotFound || resp.Status != "404 Not Found" {
+		t.Fatalf("expected 404, got %+v %v", resp, err)
+	}
+}
+
+func TestHandlerOverHTTP(t *testing.T) {
+	srv := httptest.NewServer(New(writeSnapshot(t)))
+	defer srv.Close()
+
+	sdk := tcgdex.New(client.WithBaseURL(srv.URL + "/v2/en"))
+	cards, err := sdk.Card.List(context.Background(), query.New().In("types", "Fire", "Water"))
+	if err != nil || len(cards) != 1 || cards[0].ID != "base1-4" {
+		t.Fatalf("unexpected cards %+v %v", cards, err)
+	}
+
+	for path, want := range map[string]int{
+		"/v2/en/sets/base1":     http.StatusOK,
+		"/v2/../cards":          http.StatusNotFound,
+		"/v2/en/random/serie":   http.StatusOK,
+		"/v2/en/random/unknown": http.StatusNotFound,
+		"/v2/en":                http.StatusNotFound,
+	} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Fatalf("%s: got status %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+
+	resp, err := http.Post(srv.URL+"/v2/en/cards", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", resp.StatusCode)
+	}
+}
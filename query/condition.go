@@ -0,0 +1,124 @@
+package query
+
+// Operator is the prefix of a filter value, such as "eq" in eq:Fire.
+type Operator string
+
+const (
+	// OpContains matches values containing the filter, ignoring case. It has
+	// no prefix in the query string.
+	OpContains    Operator = ""
+	OpEqual       Operator = "eq"
+	OpNotEqual    Operator = "neq"
+	OpGTE         Operator = "gte"
+	OpLTE         Operator = "lte"
+	OpGT          Operator = "gt"
+	OpLT          Operator = "lt"
+	OpNull        Operator = "null"
+	OpNotNull     Operator = "notnull"
+	OpNotContains Operator = "not"
+)
+
+// Condition is one test applied to a field. Several conditions on the same
+// field can be combined into an OR group with Query.AnyOf.
+type Condition struct {
+	Op    Operator
+	Value string
+}
+
+// String returns the condition in the API's filter syntax, e.g. "gte:100".
+func (c Condition) String() string {
+	if c.Op == OpContains {
+		return c.Value
+	}
+	return string(c.Op) + ":" + c.Value
+}
+
+func Contains(value string) Condition {
+	return Condition{OpContains, value}
+}
+
+func NotContains(value string) Condition {
+	return Condition{OpNotContains, value}
+}
+
+func Equal(value string) Condition {
+	return Condition{OpEqual, value}
+}
+
+func NotEqual(value string) Condition {
+	return Condition{OpNotEqual, value}
+}
+
+func GTE(value float64) Condition {
+	return Condition{OpGTE, formatNumber(value)}
+}
+
+func LTE(value float64) Condition {
+	return Condition{OpLTE, formatNumber(value)}
+}
+
+func GT(value float64) Condition {
+	return Condition{OpGT, formatNumber(value)}
+}
+
+func LT(value float64) Condition {
+	return Condition{OpLT, formatNumber(value)}
+}
+
+func IsNull() Condition {
+	return Condition{OpNull, ""}
+}
+
+func NotNull() Condition {
+	return Condition{OpNotNull, ""}
+}
+
+// NumberCondition is a condition that is valid on numeric fields. It is built
+// with Number and used by NumberField.AnyOf, so an OR group on a number cannot
+// hold text conditions.
+type NumberCondition struct {
+	cond Condition
+}
+
+// Condition returns the untyped condition.
+func (c NumberCondition) Condition() Condition {
+	return c.cond
+}
+
+// Number builds conditions for numeric fields, e.g.
+// CardFields.HP.AnyOf(query.Number.LTE(60), query.Number.GTE(200)).
+var Number numberConditions
+
+type numberConditions struct{}
+
+func (numberConditions) Equal(v float64) NumberCondition {
+	return NumberCondition{Condition{OpEqual, formatNumber(v)}}
+}
+
+func (numberConditions) NotEqual(v float64) NumberCondition {
+	return NumberCondition{Condition{OpNotEqual, formatNumber(v)}}
+}
+
+func (numberConditions) GTE(v float64) NumberCondition {
+	return NumberCondition{GTE(v)}
+}
+
+func (numberConditions) LTE(v float64) NumberCondition {
+	return NumberCondition{LTE(v)}
+}
+
+func (numberConditions) GT(v float64) NumberCondition {
+	return NumberCondition{GT(v)}
+}
+
+func (numberConditions) LT(v float64) NumberCondition {
+	return NumberCondition{LT(v)}
+}
+
+func (numberConditions) IsNull() NumberCondition {
+	return NumberCondition{IsNull()}
+}
+
+func (numberConditions) NotNull() NumberCondition {
+	return NumberCondition{NotNull()}
+}
@@ -0,0 +1,245 @@
+package query
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultItemsPerPage is the page size Apply uses when a page is requested
+// without a page size.
+const DefaultItemsPerPage = 100
+
+// Apply evaluates q against items the way the API does: it keeps the items
+// matching every filter, sorts them and returns the requested page. Fields are
+// addressed by their JSON names, with dots for nested fields such as
+// "set.id" or "attacks.name". A nil query returns items unchanged.
+//
+// Filters on lists match when any element matches, except not: and neq:,
+// which require that no element matches. Contains filters ignore case, eq:
+// and neq: compare exactly, and the ordering filters compare numerically when
+// both sides are numbers and as text otherwise.
+func Apply[T any](items []T, q *Query) ([]T, error) {
+	if q == nil {
+		return items, nil
+	}
+	docs := make([]any, len(items))
+	for i, item := range items {
+		doc, err := toDocument(item)
+		if err != nil {
+			return nil, err
+		}
+		docs[i] = doc
+	}
+
+	filters := q.Filters()
+	var idx []int
+	for i, doc := range docs {
+		if matchesAll(doc, filters) {
+			idx = append(idx, i)
+		}
+	}
+
+	if field, order, ok := q.SortBy(); ok {
+		desc := strings.EqualFold(order, string(Desc))
+		slices.SortStableFunc(idx, func(a, b int) int {
+			return compareForSort(lookup(docs[a], field), lookup(docs[b], field), desc)
+		})
+	}
+
+	idx = paginate(idx, q)
+	out := make([]T, len(idx))
+	for i, j := range idx {
+		out[i] = items[j]
+	}
+	return out, nil
+}
+
+// Matches reports whether item satisfies every filter of q. Sort and
+// pagination params are ignored.
+func Matches(item any, q *Query) (bool, error) {
+	if q == nil {
+		return true, nil
+	}
+	doc, err := toDocument(item)
+	if err != nil {
+		return false, err
+	}
+	return matchesAll(doc, q.Filters()), nil
+}
+
+func toDocument(item any) (any, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func matchesAll(doc any, filters []Filter) bool {
+	for _, f := range filters {
+		if !matchesAny(lookup(doc, f.Field), f.Conditions) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAny(values []any, conds []Condition) bool {
+	for _, c := range conds {
+		if matches(values, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches evaluates one condition against the values found for a field.
+func matches(values []any, c Condition) bool {
+	switch c.Op {
+	case OpNull:
+		return len(values) == 0
+	case OpNotNull:
+		return len(values) > 0
+	case OpNotEqual:
+		return !slices.ContainsFunc(values, func(v any) bool { return test(v, Condition{OpEqual, c.Value}) })
+	case OpNotContains:
+		return !slices.ContainsFunc(values, func(v any) bool { return test(v, Condition{OpContains, c.Value}) })
+	}
+	return slices.ContainsFunc(values, func(v any) bool { return test(v, c) })
+}
+
+// test evaluates a positive condition against a single scalar value.
+func test(v any, c Condition) bool {
+	s := scalarString(v)
+	switch c.Op {
+	case OpContains:
+		if _, isNumber := v.(float64); isNumber {
+			return numbersEqual(s, c.Value)
+		}
+		return strings.Contains(strings.ToLower(s), strings.ToLower(c.Value))
+	case OpEqual:
+		if _, isNumber := v.(float64); isNumber {
+			return numbersEqual(s, c.Value)
+		}
+		return s == c.Value
+	case OpGTE:
+		return compareValues(v, c.Value) >= 0
+	case OpLTE:
+		return compareValues(v, c.Value) <= 0
+	case OpGT:
+		return compareValues(v, c.Value) > 0
+	case OpLT:
+		return compareValues(v, c.Value) < 0
+	}
+	return false
+}
+
+// lookup returns the non-null scalar values found at the dotted path in doc,
+// flattening lists along the way.
+func lookup(doc any, path string) []any {
+	current := []any{doc}
+	for _, key := range strings.Split(path, ".") {
+		var next []any
+		for _, v := range flatten(current) {
+			if m, ok := v.(map[string]any); ok {
+				if child, ok := m[key]; ok && child != nil {
+					next = append(next, child)
+				}
+			}
+		}
+		current = next
+	}
+	return flatten(current)
+}
+
+func flatten(values []any) []any {
+	var out []any
+	for _, v := range values {
+		if list, ok := v.([]any); ok {
+			out = append(out, flatten(list)...)
+		} else if v != nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func scalarString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func numbersEqual(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return x == y
+}
+
+// compareValues compares v with a filter value, numerically when both are
+// numbers and as text otherwise.
+func compareValues(v any, filter string) int {
+	s := scalarString(v)
+	x, errA := strconv.ParseFloat(s, 64)
+	y, errB := strconv.ParseFloat(filter, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(s, filter)
+}
+
+// compareForSort orders items by the first value of the sort field. Items
+// without a value always sort last.
+func compareForSort(a, b []any, desc bool) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	cmp := compareValues(a[0], scalarString(b[0]))
+	if desc {
+		return -cmp
+	}
+	return cmp
+}
+
+func paginate(idx []int, q *Query) []int {
+	page, size := q.Pagination()
+	if page <= 0 && size <= 0 {
+		return idx
+	}
+	page = max(page, 1)
+	if size <= 0 {
+		size = DefaultItemsPerPage
+	}
+	start := (page - 1) * size
+	if start >= len(idx) {
+		return nil
+	}
+	return idx[start:min(start+size, len(idx))]
+}
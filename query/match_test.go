@@ -0,0 +1,171 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/laiambryant/tcgdex/models"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func attack(name string, damage models.Damage, cost ...string) models.CardAttack {
+	return models.CardAttack{Name: ptr(name), Cost: cost, Damage: &damage}
+}
+
+func card(id, name string, hp *int, rarity, set string, types []string, illustrator *string, attacks ...models.CardAttack) models.Card {
+	return models.Card{
+		CardResume:  models.CardResume{ID: id, Name: name},
+		DexID:       dexIDs[name],
+		Illustrator: illustrator,
+		Rarity:      rarity,
+		Set:         models.SetResume{ID: set},
+		HP:          hp,
+		Types:       types,
+		Attacks:     attacks,
+	}
+}
+
+var matchCards = []models.Card{
+	card("base1-4", "Charizard", ptr(120), "Rare Holo", "base1", []string{"Fire"}, ptr("Mitsuhiro Arita"),
+		attack("Fire Spin", "100", "Fire", "Fire", "Fire", "Fire")),
+	card("base1-2", "Blastoise", ptr(100), "Rare Holo", "base1", []string{"Water"}, ptr("Ken Sugimori"),
+		attack("Hydro Pump", "40+", "Water", "Water", "Water")),
+	card("base1-58", "Pikachu", ptr(40), "Common", "base1", []string{"Lightning"}, ptr("Mitsuhiro Arita"),
+		attack("Gnaw", "10", "Colorless"), attack("Thunder Jolt", "30", "Lightning", "Colorless")),
+	card("jungle-60", "Pikachu", ptr(50), "Common", "jungle", []string{"Lightning"}, nil),
+	card("base1-70", "Clefairy Doll", nil, "Rare", "base1", nil, nil),
+}
+
+var dexIDs = map[string][]int{"Charizard": {6}, "Blastoise": {9}, "Pikachu": {25}}
+
+func ids(cards []models.Card) []string {
+	out := make([]string, len(cards))
+	for i, c := range cards {
+		out[i] = c.ID
+	}
+	return out
+}
+
+func resumeIDs(cards []models.CardResume) []string {
+	out := make([]string, len(cards))
+	for i, c := range cards {
+		out[i] = c.ID
+	}
+	return out
+}
+
+func TestApplyFilters(t *testing.T) {
+	cases := []struct {
+		name string
+		q    *Query
+		want []string
+	}{
+		{"contains ignores case", New().Contains("name", "PIKA"), []string{"base1-58", "jungle-60"}},
+		{"contains on number is equality", New().Contains("hp", "10"), nil},
+		{"equal", New().Equal("name", "Pikachu"), []string{"base1-58", "jungle-60"}},
+		{"equal is exact", New().Equal("name", "pikachu"), nil},
+		{"not equal", New().NotEqual("set.id", "base1"), []string{"jungle-60"}},
+		{"not contains", New().NotContains("name", "KA"), []string{"base1-4", "base1-2", "base1-70"}},
+		{"gte", New().GTE("hp", 100), []string{"base1-4", "base1-2"}},
+		{"lt", New().LT("hp", 50), []string{"base1-58"}},
+		{"is null", New().IsNull("hp"), []string{"base1-70"}},
+		{"not null", New().NotNull("illustrator"), []string{"base1-4", "base1-2", "base1-58"}},
+		{"rarity", New().Equal("rarity", "Common"), []string{"base1-58", "jungle-60"}},
+		{"rarity in", New().In("rarity", "Rare", "Rare Holo"), []string{"base1-4", "base1-2", "base1-70"}},
+		{"list any element", New().Equal("types", "Water"), []string{"base1-2"}},
+		{"nested list", New().Contains("attacks.name", "jolt"), []string{"base1-58"}},
+		{"nested list not equal", New().NotIn("attacks.name", "Gnaw"), []string{"base1-4", "base1-2", "jungle-60", "base1-70"}},
+		{"nested list of lists", New().Equal("attacks.cost", "Colorless"), []string{"base1-58"}},
+		{"nested damage", New().Equal("attacks.damage", "40+"), []string{"base1-2"}},
+		{"nested damage compares numbers", New().LT("attacks.damage", 20), []string{"base1-58"}},
+		{"or group", New().In("types", "Fire", "Water"), []string{"base1-4", "base1-2"}},
+		{"any of", New().AnyOf("hp", LTE(40), GTE(120)), []string{"base1-4", "base1-58"}},
+		{"and", New().Equal("name", "Pikachu").Equal("set.id", "jungle"), []string{"jungle-60"}},
+		{"typed", Cards().Where(CardFields.HP.GT(45), CardFields.Illustrator.Contains("arita")).Query(), []string{"base1-4"}},
+		{"typed number list", Cards().Where(CardFields.DexID.In(6, 9)).Query(), []string{"base1-4", "base1-2"}},
+		{"typed number list compares numbers", Cards().Where(CardFields.DexID.LT(10)).Query(), []string{"base1-4", "base1-2"}},
+		{"typed any of", Cards().Where(CardFields.Rarity.Equal("Common"), CardFields.HP.AnyOf(Number.GTE(50), Number.IsNull())).Query(), []string{"jungle-60"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Apply(matchCards, tc.q)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if g := ids(got); !reflect.DeepEqual(g, tc.want) && !(len(g) == 0 && len(tc.want) == 0) {
+				t.Fatalf("got %v, want %v", g, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplySortAndPagination(t *testing.T) {
+	got, err := Apply(matchCards, New().Sort("hp", "DESC"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"base1-4", "base1-2", "jungle-60", "base1-58", "base1-70"}; !reflect.DeepEqual(ids(got), want) {
+		t.Fatalf("desc: got %v, want %v", ids(got), want)
+	}
+
+	got, _ = Apply(matchCards, New().Sort("name", "asc").Paginate(2, 2))
+	if want := []string{"base1-70", "base1-58"}; !reflect.DeepEqual(ids(got), want) {
+		t.Fatalf("page 2: got %v, want %v", ids(got), want)
+	}
+
+	got, _ = Apply(matchCards, New().Paginate(4, 2))
+	if len(got) != 0 {
+		t.Fatalf("expected empty page past the end, got %v", ids(got))
+	}
+}
+
+func TestApplyCardResumes(t *testing.T) {
+	resumes := []models.CardResume{
+		{ID: "base1-4", LocalID: "4", Name: "Charizard", Image: ptr("https://assets.tcgdex.net/en/base/base1/4")},
+		{ID: "base1-58", LocalID: "58", Name: "Pikachu", Image: ptr("https://assets.tcgdex.net/en/base/base1/58")},
+		{ID: "jungle-60", LocalID: "60", Name: "Pikachu"},
+	}
+	got, err := Apply(resumes, New().Contains("name", "pika").Sort("localId", "DESC"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"jungle-60", "base1-58"}; !reflect.DeepEqual(resumeIDs(got), want) {
+		t.Fatalf("got %v, want %v", resumeIDs(got), want)
+	}
+
+	got, _ = Apply(resumes, New().IsNull("image"))
+	if want := []string{"jungle-60"}; !reflect.DeepEqual(resumeIDs(got), want) {
+		t.Fatalf("null image: got %v, want %v", resumeIDs(got), want)
+	}
+}
+
+func TestApplyParsedQuery(t *testing.T) {
+	q, err := Parse("name=eq:Pikachu&sort%3Afield=hp&sort%3Aorder=DESC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := Apply(matchCards, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"jungle-60", "base1-58"}; !reflect.DeepEqual(ids(got), want) {
+		t.Fatalf("got %v, want %v", ids(got), want)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	ok, err := Matches(matchCards[0], New().Equal("types", "Fire").Sort("name", "ASC"))
+	if err != nil || !ok {
+		t.Fatalf("expected match, got %v, %v", ok, err)
+	}
+	ok, _ = Matches(&matchCards[0], New().Equal("types", "Water"))
+	if ok {
+		t.Fatal("expected no match")
+	}
+	if _, err := Matches(func() {}, New()); err == nil {
+		t.Fatal("expected error for a value that cannot be encoded")
+	}
+}
@@ -0,0 +1,138 @@
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	keySortField    = "sort:field"
+	keySortOrder    = "sort:order"
+	keyPage         = "pagination:page"
+	keyItemsPerPage = "pagination:itemsPerPage"
+)
+
+// ParseError reports a query string param that could not be decoded.
+type ParseError struct {
+	Param string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse error for param %q: %v", e.Param, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Filter is a filter on one field: a single condition, or several forming an
+// OR group.
+type Filter struct {
+	Field      string
+	Conditions []Condition
+}
+
+// Parse reconstructs a query from a query string such as the one returned by
+// Build, with or without the leading "?". Params keep their order, filter
+// prefixes like eq: or notnull: are decoded into conditions and pipe-separated
+// values into OR groups.
+func Parse(rawQuery string) (*Query, error) {
+	q := New()
+	for _, part := range strings.Split(strings.TrimPrefix(rawQuery, "?"), "&") {
+		if part == "" {
+			continue
+		}
+		rawKey, rawValue, _ := strings.Cut(part, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			return nil, &ParseError{Param: rawKey, Err: err}
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			return nil, &ParseError{Param: key, Err: err}
+		}
+		if isControlKey(key) {
+			q.add(key, Contains(value))
+			continue
+		}
+		alternatives := strings.Split(value, "|")
+		conds := make([]Condition, len(alternatives))
+		for i, a := range alternatives {
+			conds[i] = parseCondition(a)
+		}
+		q.add(key, conds...)
+	}
+	return q, nil
+}
+
+// Filters returns the filters of the query in order, leaving out sort and
+// pagination params.
+func (q *Query) Filters() []Filter {
+	var filters []Filter
+	for _, p := range q.params {
+		if isControlKey(p.key) {
+			continue
+		}
+		filters = append(filters, Filter{Field: p.key, Conditions: append([]Condition(nil), p.conds...)})
+	}
+	return filters
+}
+
+// SortBy returns the sort field and order set on the query. When sorting is
+// repeated, the last value wins.
+func (q *Query) SortBy() (field, order string, ok bool) {
+	for _, p := range q.params {
+		switch p.key {
+		case keySortField:
+			field, ok = p.raw(), true
+		case keySortOrder:
+			order = p.raw()
+		}
+	}
+	return field, order, ok
+}
+
+// RemoveFilters removes every filter on field.
+func (q *Query) RemoveFilters(field string) *Query {
+	return q.remove(func(p param) bool { return !isControlKey(p.key) && p.key == field })
+}
+
+// RemoveSort removes the sort params.
+func (q *Query) RemoveSort() *Query {
+	return q.remove(func(p param) bool { return p.key == keySortField || p.key == keySortOrder })
+}
+
+func (q *Query) remove(match func(param) bool) *Query {
+	params := q.params[:0]
+	for _, p := range q.params {
+		if !match(p) {
+			params = append(params, p)
+		}
+	}
+	q.params = params
+	return q
+}
+
+// isControlKey reports whether key is a sort or pagination param rather than
+// a filter.
+func isControlKey(key string) bool {
+	switch key {
+	case keySortField, keySortOrder, keyPage, keyItemsPerPage:
+		return true
+	}
+	return false
+}
+
+// parseCondition splits a single filter value such as "eq:Fire" into its
+// operator and value. Values without a known prefix are contains filters.
+func parseCondition(s string) Condition {
+	if prefix, value, ok := strings.Cut(s, ":"); ok {
+		switch op := Operator(prefix); op {
+		case OpEqual, OpNotEqual, OpGTE, OpLTE, OpGT, OpLT, OpNull, OpNotNull, OpNotContains:
+			return Condition{op, value}
+		}
+	}
+	return Condition{OpContains, s}
+}
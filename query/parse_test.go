@@ -0,0 +1,100 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	queries := []*Query{
+		New(),
+		New().Contains("name", "bob").Equal("status", "active").NotEqual("role", "admin").
+			GTE("score", 10).LTE("rank", 5).GT("visits", 100).LT("errors", 2).
+			IsNull("deleted_at").NotNull("created_at").NotContains("desc", "spoiler").
+			Sort("title", "desc").Paginate(3, 25),
+		New().In("types", "Fire", "Water").AnyOf("hp", LTE(60), GTE(200)).NotIn("rarity", "Common", "Rare Holo"),
+		New().Contains("a b", "c&d=!").Equal("name", "Farfetch'd"),
+		Cards().Where(CardFields.HP.In(60, 70)).Sort(CardFields.Name, Asc).Query(),
+	}
+	for _, q := range queries {
+		built := q.Build()
+		parsed, err := Parse(built)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", built, err)
+		}
+		if got := parsed.Build(); got != built {
+			t.Fatalf("round trip mismatch:\n got: %s\nwant: %s", got, built)
+		}
+	}
+}
+
+func TestParseFiltersSortAndPagination(t *testing.T) {
+	q, err := Parse("name=pika&types=eq:Fire|eq:Water&hp=gte%3A60&illustrator=notnull:&evolveFrom=null:" +
+		"&desc=not:ex&sort%3Afield=name&sort%3Aorder=DESC&pagination%3Apage=2&pagination%3AitemsPerPage=50&time=12:30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Filter{
+		{"name", []Condition{{OpContains, "pika"}}},
+		{"types", []Condition{{OpEqual, "Fire"}, {OpEqual, "Water"}}},
+		{"hp", []Condition{{OpGTE, "60"}}},
+		{"illustrator", []Condition{{OpNotNull, ""}}},
+		{"evolveFrom", []Condition{{OpNull, ""}}},
+		{"desc", []Condition{{OpNotContains, "ex"}}},
+		{"time", []Condition{{OpContains, "12:30"}}},
+	}
+	if got := q.Filters(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected filters:\n got: %+v\nwant: %+v", got, want)
+	}
+	if field, order, ok := q.SortBy(); !ok || field != "name" || order != "DESC" {
+		t.Fatalf("unexpected sort %q %q %v", field, order, ok)
+	}
+	if page, size := q.Pagination(); page != 2 || size != 50 {
+		t.Fatalf("unexpected pagination %d %d", page, size)
+	}
+}
+
+func TestParseAcceptsEncodedPipesAndLeadingMark(t *testing.T) {
+	values := url.Values{"types": {"eq:Fire|eq:Water"}}
+	q, err := Parse("?" + values.Encode())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f := q.Filters(); len(f) != 1 || len(f[0].Conditions) != 2 {
+		t.Fatalf("expected an OR group, got %+v", f)
+	}
+	q, err = Parse("&&name&")
+	if err != nil || len(q.Filters()) != 1 || q.Filters()[0].Conditions[0] != Contains("") {
+		t.Fatalf("unexpected parse of bare key %+v %v", q.Filters(), err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var pe *ParseError
+	if _, err := Parse("name=%zz"); !errors.As(err, &pe) || pe.Param != "name" || pe.Unwrap() == nil || pe.Error() == "" {
+		t.Fatalf("expected ParseError for value, got %v", err)
+	}
+	if _, err := Parse("%zz=1"); !errors.As(err, &pe) || pe.Param != "%zz" {
+		t.Fatalf("expected ParseError for key, got %v", err)
+	}
+}
+
+func TestEditParsedQuery(t *testing.T) {
+	q, err := Parse("name=pika&types=Fire&name=eq:Raichu&sort:field=name&sort:order=ASC&pagination:page=1&pagination:itemsPerPage=10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q.RemoveFilters("name").RemoveSort().Contains("name", "mew")
+	if _, _, ok := q.SortBy(); ok {
+		t.Fatalf("expected sort to be removed")
+	}
+	want := "?types=Fire&pagination%3Apage=1&pagination%3AitemsPerPage=10&name=mew"
+	if got := q.Build(); got != want {
+		t.Fatalf("unexpected query:\n got: %s\nwant: %s", got, want)
+	}
+	if q.RemoveFilters("pagination:page"); q.Build() != want {
+		t.Fatalf("RemoveFilters must not touch pagination")
+	}
+}
@@ -1,99 +1,172 @@
 package query
 
 import (
-"fmt"
-"net/url"
-"strings"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 type Query struct {
 	params []param
 }
 
+// param is one key of the query string. Filters hold one condition, or
+// several when they form an OR group; sort and pagination params hold their
+// raw value as a single contains condition.
 type param struct {
 	key   string
-	value string
+	conds []Condition
 }
 
 func New() *Query {
 	return &Query{}
 }
 
-func (q *Query) add(key, value string) {
-	q.params = append(q.params, param{key, value})
+func (q *Query) add(key string, conds ...Condition) {
+	q.params = append(q.params, param{key, conds})
 }
 
 func (q *Query) Contains(key, value string) *Query {
-	q.add(key, value)
+	q.add(key, Contains(value))
 	return q
 }
 
 func (q *Query) Equal(key, value string) *Query {
-	q.add(key, "eq:"+value)
+	q.add(key, Equal(value))
 	return q
 }
 
 func (q *Query) NotEqual(key, value string) *Query {
-	q.add(key, "neq:"+value)
+	q.add(key, NotEqual(value))
 	return q
 }
 
 func (q *Query) GTE(key string, value int) *Query {
-	q.add(key, fmt.Sprintf("gte:%d", value))
+	q.add(key, GTE(float64(value)))
 	return q
 }
 
 func (q *Query) LTE(key string, value int) *Query {
-	q.add(key, fmt.Sprintf("lte:%d", value))
+	q.add(key, LTE(float64(value)))
 	return q
 }
 
 func (q *Query) GT(key string, value int) *Query {
-	q.add(key, fmt.Sprintf("gt:%d", value))
+	q.add(key, GT(float64(value)))
 	return q
 }
 
 func (q *Query) LT(key string, value int) *Query {
-	q.add(key, fmt.Sprintf("lt:%d", value))
+	q.add(key, LT(float64(value)))
 	return q
 }
 
 func (q *Query) IsNull(key string) *Query {
-	q.add(key, "null:")
+	q.add(key, IsNull())
 	return q
 }
 
 func (q *Query) NotNull(key string) *Query {
-	q.add(key, "notnull:")
+	q.add(key, NotNull())
 	return q
 }
 
 func (q *Query) NotContains(key, value string) *Query {
-	q.add(key, "not:"+value)
+	q.add(key, NotContains(value))
+	return q
+}
+
+// In matches items whose key equals any of values, e.g. types=eq:Fire|eq:Water.
+// It adds nothing when values is empty.
+func (q *Query) In(key string, values ...string) *Query {
+	conds := make([]Condition, len(values))
+	for i, v := range values {
+		conds[i] = Equal(v)
+	}
+	return q.AnyOf(key, conds...)
+}
+
+// NotIn matches items whose key equals none of values. The API has no
+// negated OR, so this adds one neq filter per value, which the API combines
+// with AND.
+func (q *Query) NotIn(key string, values ...string) *Query {
+	for _, v := range values {
+		q.add(key, NotEqual(v))
+	}
+	return q
+}
+
+// AnyOf matches items for which at least one of conds holds on key, e.g.
+// AnyOf("hp", LTE(60), GTE(200)) builds hp=lte:60|gte:200. The API only
+// supports alternatives within a single field. It adds nothing when conds is
+// empty.
+func (q *Query) AnyOf(key string, conds ...Condition) *Query {
+	if len(conds) > 0 {
+		q.add(key, conds...)
+	}
 	return q
 }
 
 func (q *Query) Sort(field, order string) *Query {
-	q.add("sort:field", field)
-	q.add("sort:order", order)
+	q.add(keySortField, Contains(field))
+	q.add(keySortOrder, Contains(order))
 	return q
 }
 
 func (q *Query) Paginate(page, itemsPerPage int) *Query {
-	q.add("pagination:page", fmt.Sprintf("%d", page))
-	q.add("pagination:itemsPerPage", fmt.Sprintf("%d", itemsPerPage))
+	q.add(keyPage, Contains(strconv.Itoa(page)))
+	q.add(keyItemsPerPage, Contains(strconv.Itoa(itemsPerPage)))
 	return q
 }
 
+// Clone returns an independent copy of the query.
+func (q *Query) Clone() *Query {
+	return &Query{params: append([]param(nil), q.params...)}
+}
+
+// Pagination returns the page and page size set on the query, or zero for
+// values that are not set. When pagination is repeated, the last value wins.
+func (q *Query) Pagination() (page, itemsPerPage int) {
+	for _, p := range q.params {
+		switch p.key {
+		case keyPage:
+			page, _ = strconv.Atoi(p.raw())
+		case keyItemsPerPage:
+			itemsPerPage, _ = strconv.Atoi(p.raw())
+		}
+	}
+	return page, itemsPerPage
+}
+
+// SetPage is like Paginate but replaces any pagination already on the query.
+func (q *Query) SetPage(page, itemsPerPage int) *Query {
+	q.remove(func(p param) bool { return p.key == keyPage || p.key == keyItemsPerPage })
+	return q.Paginate(page, itemsPerPage)
+}
+
+// Build encodes the query string. Each alternative of an OR group is escaped
+// on its own and joined with a literal pipe, the separator the API splits on.
 func (q *Query) Build() string {
 	if len(q.params) == 0 {
 		return ""
 	}
 	var parts []string
 	for _, p := range q.params {
-		k := url.QueryEscape(p.key)
-		v := url.QueryEscape(p.value)
-		parts = append(parts, fmt.Sprintf("%s=%s", k, v))
+		values := make([]string, len(p.conds))
+		for i, c := range p.conds {
+			values[i] = url.QueryEscape(c.String())
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", url.QueryEscape(p.key), strings.Join(values, "|")))
 	}
 	return "?" + strings.Join(parts, "&")
 }
+
+// raw returns the unparsed value of the param.
+func (p param) raw() string {
+	values := make([]string, len(p.conds))
+	for i, c := range p.conds {
+		values[i] = c.String()
+	}
+	return strings.Join(values, "|")
+}
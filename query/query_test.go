@@ -70,3 +70,83 @@ func TestQueryEscaping(t *testing.T) {
 		t.Fatalf("escaping mismatch: got %q want %q", got, expected)
 	}
 }
+
+func TestCloneAndPagination(t *testing.T) {
+	q := New().Contains("name", "bob")
+	if page, size := q.Pagination(); page != 0 || size != 0 {
+		t.Fatalf("expected no pagination, got %d %d", page, size)
+	}
+	q.Paginate(2, 50)
+	c := q.Clone()
+	c.SetPage(3, 10)
+	if page, size := q.Pagination(); page != 2 || size != 50 {
+		t.Fatalf("clone should not affect original, got %d %d", page, size)
+	}
+	if page, size := c.Pagination(); page != 3 || size != 10 {
+		t.Fatalf("unexpected clone pagination %d %d", page, size)
+	}
+	want := "?name=bob&pagination%3Apage=3&pagination%3AitemsPerPage=10"
+	if got := c.Build(); got != want {
+		t.Fatalf("unexpected query: got %s want %s", got, want)
+	}
+}
+
+func TestOrGroupEncoding(t *testing.T) {
+	cases := []struct {
+		name string
+		q    *Query
+		want string
+	}{
+		{"In", New().In("types", "Fire", "Water"), "?types=eq%3AFire|eq%3AWater"},
+		{"InSingle", New().In("types", "Fire"), "?types=eq%3AFire"},
+		{"InEmpty", New().In("types"), ""},
+		{"InEscapesEachValue", New().In("name", "Mr. Mime", "Farfetch'd"), "?name=eq%3AMr.+Mime|eq%3AFarfetch%27d"},
+		{"NotIn", New().NotIn("rarity", "Common", "Uncommon"), "?rarity=neq%3ACommon&rarity=neq%3AUncommon"},
+		{"AnyOf", New().AnyOf("hp", LTE(60), GTE(200)), "?hp=lte%3A60|gte%3A200"},
+		{"AnyOfMixed", New().AnyOf("name", Contains("pika"), Equal("Raichu"), IsNull()), "?name=pika|eq%3ARaichu|null%3A"},
+		{"AnyOfEmpty", New().AnyOf("hp"), ""},
+		{"Combined", New().In("types", "Fire", "Water").GTE("hp", 100), "?types=eq%3AFire|eq%3AWater&hp=gte%3A100"},
+	}
+	for _, tc := range cases {
+		if got := tc.q.Build(); got != tc.want {
+			t.Fatalf("%s: got %s want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestConditions(t *testing.T) {
+	cases := map[Condition]string{
+		Contains("a"):    "a",
+		NotContains("a"): "not:a",
+		Equal("a"):       "eq:a",
+		NotEqual("a"):    "neq:a",
+		GTE(1.5):         "gte:1.5",
+		LTE(2):           "lte:2",
+		GT(3):            "gt:3",
+		LT(-4):           "lt:-4",
+		IsNull():         "null:",
+		NotNull():        "notnull:",
+		Equal("a:b"):     "eq:a:b",
+		Contains("b:c"):  "b:c",
+	}
+	for c, want := range cases {
+		if got := c.String(); got != want {
+			t.Fatalf("got %s want %s", got, want)
+		}
+	}
+	numbers := map[NumberCondition]string{
+		Number.Equal(1):    "eq:1",
+		Number.NotEqual(2): "neq:2",
+		Number.GTE(1.5):    "gte:1.5",
+		Number.LTE(2):      "lte:2",
+		Number.GT(3):       "gt:3",
+		Number.LT(-4):      "lt:-4",
+		Number.IsNull():    "null:",
+		Number.NotNull():   "notnull:",
+	}
+	for c, want := range numbers {
+		if got := c.Condition().String(); got != want {
+			t.Fatalf("got %s want %s", got, want)
+		}
+	}
+}
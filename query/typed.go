@@ -0,0 +1,407 @@
+package query
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/laiambryant/tcgdex/models"
+)
+
+// Order is a sort direction.
+type Order string
+
+const (
+	Asc  Order = "ASC"
+	Desc Order = "DESC"
+)
+
+// Typed is a query bound to the resource R, such as models.Card. Its filters
+// can only be built from field descriptors of R (see CardFields, SetFields and
+// SerieFields), so a query meant for one resource cannot be sent to another.
+type Typed[R any] struct {
+	q *Query
+}
+
+// For returns an empty query for the resource R.
+func For[R any]() *Typed[R] {
+	return &Typed[R]{q: New()}
+}
+
+// Cards returns an empty query for cards.
+func Cards() *Typed[models.Card] {
+	return For[models.Card]()
+}
+
+// Sets returns an empty query for sets.
+func Sets() *Typed[models.Set] {
+	return For[models.Set]()
+}
+
+// Series returns an empty query for series.
+func Series() *Typed[models.Serie] {
+	return For[models.Serie]()
+}
+
+// Where adds the given filters.
+func (t *Typed[R]) Where(preds ...Predicate[R]) *Typed[R] {
+	for _, p := range preds {
+		t.q.params = append(t.q.params, p.params...)
+	}
+	return t
+}
+
+func (t *Typed[R]) Sort(field Field[R], order Order) *Typed[R] {
+	t.q.Sort(field.Name(), string(order))
+	return t
+}
+
+func (t *Typed[R]) Paginate(page, itemsPerPage int) *Typed[R] {
+	t.q.Paginate(page, itemsPerPage)
+	return t
+}
+
+// Query returns the untyped query the filters are collected in.
+func (t *Typed[R]) Query() *Query {
+	return t.q
+}
+
+func (t *Typed[R]) Build() string {
+	return t.q.Build()
+}
+
+// Predicate is a filter on a field of the resource R.
+type Predicate[R any] struct {
+	params []param
+}
+
+func predicate[R any](key string, conds ...Condition) Predicate[R] {
+	return Predicate[R]{params: []param{{key, conds}}}
+}
+
+func inPredicate[R any](key string, values []string) Predicate[R] {
+	q := New().In(key, values...)
+	return Predicate[R]{params: q.params}
+}
+
+func notInPredicate[R any](key string, values []string) Predicate[R] {
+	q := New().NotIn(key, values...)
+	return Predicate[R]{params: q.params}
+}
+
+// Field is implemented by every field descriptor of the resource R.
+type Field[R any] interface {
+	// Name returns the JSON name of the field, as used by the API.
+	Name() string
+	field(R)
+}
+
+// StringField describes a text field.
+type StringField[R any] struct{ name string }
+
+func (f StringField[R]) Name() string {
+	return f.name
+}
+
+func (StringField[R]) field(R) {}
+
+func (f StringField[R]) Contains(v string) Predicate[R] {
+	return predicate[R](f.name, Contains(v))
+}
+
+func (f StringField[R]) NotContains(v string) Predicate[R] {
+	return predicate[R](f.name, NotContains(v))
+}
+
+func (f StringField[R]) Equal(v string) Predicate[R] {
+	return predicate[R](f.name, Equal(v))
+}
+
+func (f StringField[R]) NotEqual(v string) Predicate[R] {
+	return predicate[R](f.name, NotEqual(v))
+}
+
+func (f StringField[R]) IsNull() Predicate[R] {
+	return predicate[R](f.name, IsNull())
+}
+
+func (f StringField[R]) NotNull() Predicate[R] {
+	return predicate[R](f.name, NotNull())
+}
+
+// In matches when the field equals any of values.
+func (f StringField[R]) In(values ...string) Predicate[R] {
+	return inPredicate[R](f.name, values)
+}
+
+// NotIn matches when the field equals none of values.
+func (f StringField[R]) NotIn(values ...string) Predicate[R] {
+	return notInPredicate[R](f.name, values)
+}
+
+// NumberField describes a numeric field.
+type NumberField[R any] struct{ name string }
+
+func (f NumberField[R]) Name() string {
+	return f.name
+}
+
+func (NumberField[R]) field(R) {}
+
+func (f NumberField[R]) Equal(v float64) Predicate[R] {
+	return f.AnyOf(Number.Equal(v))
+}
+
+func (f NumberField[R]) NotEqual(v float64) Predicate[R] {
+	return f.AnyOf(Number.NotEqual(v))
+}
+
+func (f NumberField[R]) GTE(v float64) Predicate[R] {
+	return f.AnyOf(Number.GTE(v))
+}
+
+func (f NumberField[R]) LTE(v float64) Predicate[R] {
+	return f.AnyOf(Number.LTE(v))
+}
+
+func (f NumberField[R]) GT(v float64) Predicate[R] {
+	return f.AnyOf(Number.GT(v))
+}
+
+func (f NumberField[R]) LT(v float64) Predicate[R] {
+	return f.AnyOf(Number.LT(v))
+}
+
+func (f NumberField[R]) IsNull() Predicate[R] {
+	return f.AnyOf(Number.IsNull())
+}
+
+func (f NumberField[R]) NotNull() Predicate[R] {
+	return f.AnyOf(Number.NotNull())
+}
+
+// In matches when the field equals any of values.
+func (f NumberField[R]) In(values ...float64) Predicate[R] {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = formatNumber(v)
+	}
+	return inPredicate[R](f.name, strs)
+}
+
+// AnyOf matches when at least one of conds holds, e.g.
+// AnyOf(query.Number.LTE(60), query.Number.GTE(200)).
+func (f NumberField[R]) AnyOf(conds ...NumberCondition) Predicate[R] {
+	if len(conds) == 0 {
+		return Predicate[R]{}
+	}
+	c := make([]Condition, len(conds))
+	for i, cond := range conds {
+		c[i] = cond.cond
+	}
+	return predicate[R](f.name, c...)
+}
+
+// ListField describes a field holding a list of values, such as the types of
+// a card. Filters match when any element of the list matches.
+type ListField[R any] struct{ name string }
+
+func (f ListField[R]) Name() string {
+	return f.name
+}
+
+func (ListField[R]) field(R) {}
+
+func (f ListField[R]) Contains(v string) Predicate[R] {
+	return predicate[R](f.name, Contains(v))
+}
+
+func (f ListField[R]) NotContains(v string) Predicate[R] {
+	return predicate[R](f.name, NotContains(v))
+}
+
+func (f ListField[R]) Equal(v string) Predicate[R] {
+	return predicate[R](f.name, Equal(v))
+}
+
+func (f ListField[R]) NotEqual(v string) Predicate[R] {
+	return predicate[R](f.name, NotEqual(v))
+}
+
+func (f ListField[R]) IsNull() Predicate[R] {
+	return predicate[R](f.name, IsNull())
+}
+
+func (f ListField[R]) NotNull() Predicate[R] {
+	return predicate[R](f.name, NotNull())
+}
+
+// In matches when an element of the list equals any of values.
+func (f ListField[R]) In(values ...string) Predicate[R] {
+	return inPredicate[R](f.name, values)
+}
+
+// NotIn matches when no element of the list equals any of values.
+func (f ListField[R]) NotIn(values ...string) Predicate[R] {
+	return notInPredicate[R](f.name, values)
+}
+
+// NumberListField describes a field holding a list of numbers, such as the
+// National Pokédex numbers of a card. Filters match when any element of the
+// list matches.
+type NumberListField[R any] struct{ name string }
+
+func (f NumberListField[R]) Name() string {
+	return f.name
+}
+
+func (NumberListField[R]) field(R) {}
+
+func (f NumberListField[R]) Equal(v float64) Predicate[R] {
+	return f.AnyOf(Number.Equal(v))
+}
+
+func (f NumberListField[R]) NotEqual(v float64) Predicate[R] {
+	return f.AnyOf(Number.NotEqual(v))
+}
+
+func (f NumberListField[R]) GTE(v float64) Predicate[R] {
+	return f.AnyOf(Number.GTE(v))
+}
+
+func (f NumberListField[R]) LTE(v float64) Predicate[R] {
+	return f.AnyOf(Number.LTE(v))
+}
+
+func (f NumberListField[R]) GT(v float64) Predicate[R] {
+	return f.AnyOf(Number.GT(v))
+}
+
+func (f NumberListField[R]) LT(v float64) Predicate[R] {
+	return f.AnyOf(Number.LT(v))
+}
+
+func (f NumberListField[R]) IsNull() Predicate[R] {
+	return f.AnyOf(Number.IsNull())
+}
+
+func (f NumberListField[R]) NotNull() Predicate[R] {
+	return f.AnyOf(Number.NotNull())
+}
+
+// In matches when an element of the list equals any of values.
+func (f NumberListField[R]) In(values ...float64) Predicate[R] {
+	return NumberField[R](f).In(values...)
+}
+
+// AnyOf matches when at least one of conds holds for an element of the list.
+func (f NumberListField[R]) AnyOf(conds ...NumberCondition) Predicate[R] {
+	return NumberField[R](f).AnyOf(conds...)
+}
+
+// DateField describes a date field, compared at day precision.
+type DateField[R any] struct{ name string }
+
+func (f DateField[R]) Name() string {
+	return f.name
+}
+
+func (DateField[R]) field(R) {}
+
+func (f DateField[R]) Equal(v time.Time) Predicate[R] {
+	return predicate[R](f.name, Condition{OpEqual, formatDate(v)})
+}
+
+func (f DateField[R]) GTE(v time.Time) Predicate[R] {
+	return predicate[R](f.name, Condition{OpGTE, formatDate(v)})
+}
+
+func (f DateField[R]) LTE(v time.Time) Predicate[R] {
+	return predicate[R](f.name, Condition{OpLTE, formatDate(v)})
+}
+
+func (f DateField[R]) GT(v time.Time) Predicate[R] {
+	return predicate[R](f.name, Condition{OpGT, formatDate(v)})
+}
+
+func (f DateField[R]) LT(v time.Time) Predicate[R] {
+	return predicate[R](f.name, Condition{OpLT, formatDate(v)})
+}
+
+func (f DateField[R]) IsNull() Predicate[R] {
+	return predicate[R](f.name, IsNull())
+}
+
+func (f DateField[R]) NotNull() Predicate[R] {
+	return predicate[R](f.name, NotNull())
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatDate(v time.Time) string {
+	return v.Format(time.DateOnly)
+}
+
+// CardFields lists the filterable fields of cards.
+var CardFields = struct {
+	ID             StringField[models.Card]
+	LocalID        StringField[models.Card]
+	Name           StringField[models.Card]
+	Illustrator    StringField[models.Card]
+	Rarity         StringField[models.Card]
+	Category       StringField[models.Card]
+	HP             NumberField[models.Card]
+	Types          ListField[models.Card]
+	DexID          NumberListField[models.Card]
+	EvolveFrom     StringField[models.Card]
+	Description    StringField[models.Card]
+	Level          StringField[models.Card]
+	Stage          StringField[models.Card]
+	Suffix         StringField[models.Card]
+	Retreat        NumberField[models.Card]
+	RegulationMark StringField[models.Card]
+}{
+	ID:             StringField[models.Card]{"id"},
+	LocalID:        StringField[models.Card]{"localId"},
+	Name:           StringField[models.Card]{"name"},
+	Illustrator:    StringField[models.Card]{"illustrator"},
+	Rarity:         StringField[models.Card]{"rarity"},
+	Category:       StringField[models.Card]{"category"},
+	HP:             NumberField[models.Card]{"hp"},
+	Types:          ListField[models.Card]{"types"},
+	DexID:          NumberListField[models.Card]{"dexId"},
+	EvolveFrom:     StringField[models.Card]{"evolveFrom"},
+	Description:    StringField[models.Card]{"description"},
+	Level:          StringField[models.Card]{"level"},
+	Stage:          StringField[models.Card]{"stage"},
+	Suffix:         StringField[models.Card]{"suffix"},
+	Retreat:        NumberField[models.Card]{"retreat"},
+	RegulationMark: StringField[models.Card]{"regulationMark"},
+}
+
+// SetFields lists the filterable fields of sets.
+var SetFields = struct {
+	ID                StringField[models.Set]
+	Name              StringField[models.Set]
+	ReleaseDate       DateField[models.Set]
+	CardCountTotal    NumberField[models.Set]
+	CardCountOfficial NumberField[models.Set]
+}{
+	ID:                StringField[models.Set]{"id"},
+	Name:              StringField[models.Set]{"name"},
+	ReleaseDate:       DateField[models.Set]{"releaseDate"},
+	CardCountTotal:    NumberField[models.Set]{"cardCount.total"},
+	CardCountOfficial: NumberField[models.Set]{"cardCount.official"},
+}
+
+// SerieFields lists the filterable fields of series.
+var SerieFields = struct {
+	ID          StringField[models.Serie]
+	Name        StringField[models.Serie]
+	ReleaseDate DateField[models.Serie]
+}{
+	ID:          StringField[models.Serie]{"id"},
+	Name:        StringField[models.Serie]{"name"},
+	ReleaseDate: DateField[models.Serie]{"releaseDate"},
+}
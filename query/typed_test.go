@@ -0,0 +1,167 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/laiambryant/tcgdex/models"
+)
+
+func TestTypedCardQuery(t *testing.T) {
+	q := Cards().
+		Where(
+			CardFields.HP.GTE(100),
+			CardFields.HP.LT(250.5),
+			CardFields.Types.Contains("Fire"),
+			CardFields.Name.NotContains("ex"),
+			CardFields.Rarity.Equal("Rare"),
+			CardFields.Stage.NotEqual("Basic"),
+			CardFields.Illustrator.NotNull(),
+			CardFields.EvolveFrom.IsNull(),
+		).
+		Sort(CardFields.HP, Desc).
+		Paginate(1, 20)
+
+	want := url.Values{}
+	got, err := url.ParseQuery(q.Build()[1:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want["hp"] = []string{"gte:100", "lt:250.5"}
+	want["types"] = []string{"Fire"}
+	want["name"] = []string{"not:ex"}
+	want["rarity"] = []string{"eq:Rare"}
+	want["stage"] = []string{"neq:Basic"}
+	want["illustrator"] = []string{"notnull:"}
+	want["evolveFrom"] = []string{"null:"}
+	want["sort:field"] = []string{"hp"}
+	want["sort:order"] = []string{"DESC"}
+	want["pagination:page"] = []string{"1"}
+	want["pagination:itemsPerPage"] = []string{"20"}
+	if got.Encode() != want.Encode() {
+		t.Fatalf("unexpected query:\n got: %s\nwant: %s", got.Encode(), want.Encode())
+	}
+	if q.Query() == nil || q.Query().Build() != q.Build() {
+		t.Fatalf("expected the untyped query to match")
+	}
+}
+
+func TestTypedSetAndSerieQueries(t *testing.T) {
+	day := time.Date(2020, 2, 7, 15, 0, 0, 0, time.UTC)
+	q := Sets().Where(
+		SetFields.ReleaseDate.GTE(day),
+		SetFields.ReleaseDate.LT(day),
+		SetFields.CardCountOfficial.Equal(202),
+		SetFields.Name.Contains("Sword"),
+	)
+	want := "?releaseDate=gte%3A2020-02-07&releaseDate=lt%3A2020-02-07&cardCount.official=eq%3A202&name=Sword"
+	if got := q.Build(); got != want {
+		t.Fatalf("unexpected query:\n got: %s\nwant: %s", got, want)
+	}
+
+	s := Series().Where(SerieFields.ID.Equal("swsh")).Sort(SerieFields.ReleaseDate, Asc)
+	want = "?id=eq%3Aswsh&sort%3Afield=releaseDate&sort%3Aorder=ASC"
+	if got := s.Build(); got != want {
+		t.Fatalf("unexpected query:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestTypedReleaseDateMatchesModels(t *testing.T) {
+	day := time.Date(2020, 2, 7, 0, 0, 0, 0, time.UTC)
+	sets := []models.Set{
+		{SetResume: models.SetResume{ID: "sm12"}, ReleaseDate: "2019-11-01"},
+		{SetResume: models.SetResume{ID: "swsh1"}, ReleaseDate: "2020-02-07"},
+		{SetResume: models.SetResume{ID: "swsh2"}, ReleaseDate: "2020-05-01"},
+	}
+	got, err := Apply(sets, Sets().Where(SetFields.ReleaseDate.GTE(day)).Sort(SetFields.ReleaseDate, Desc).Query())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "swsh2" || got[1].ID != "swsh1" {
+		t.Fatalf("unexpected sets %+v", got)
+	}
+
+	series := []models.Serie{
+		{SerieResume: models.SerieResume{ID: "sm"}, ReleaseDate: "2017-02-03"},
+		{SerieResume: models.SerieResume{ID: "swsh"}, ReleaseDate: "2020-02-07"},
+	}
+	ok, err := Matches(series[1], Series().Where(SerieFields.ReleaseDate.GTE(day)).Query())
+	if err != nil || !ok {
+		t.Fatalf("expected swsh to match, got %v %v", ok, err)
+	}
+	if ok, _ := Matches(series[0], Series().Where(SerieFields.ReleaseDate.GTE(day)).Query()); ok {
+		t.Fatalf("expected sm not to match")
+	}
+}
+
+func TestFieldNames(t *testing.T) {
+	fields := []Field[any]{
+		StringField[any]{"a"}, NumberField[any]{"b"}, ListField[any]{"c"}, DateField[any]{"d"}, NumberListField[any]{"e"},
+	}
+	for i, f := range fields {
+		if f.Name() != string(rune('a'+i)) {
+			t.Fatalf("unexpected name %q", f.Name())
+		}
+	}
+	list := ListField[any]{"types"}
+	for _, tc := range []struct {
+		pred Predicate[any]
+		want string
+	}{
+		{list.Equal("Fire"), "eq:Fire"},
+		{list.NotEqual("Fire"), "neq:Fire"},
+		{list.NotContains("Fi"), "not:Fi"},
+		{list.IsNull(), "null:"},
+		{list.NotNull(), "notnull:"},
+	} {
+		if got := tc.pred.params[0].raw(); got != tc.want {
+			t.Fatalf("want %q got %q", tc.want, got)
+		}
+	}
+	num := NumberField[any]{"hp"}
+	dex := NumberListField[any]{"dexId"}
+	date := DateField[any]{"d"}
+	day := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		pred Predicate[any]
+		want string
+	}{
+		{num.Equal(1.5), "eq:1.5"},
+		{num.NotEqual(2), "neq:2"},
+		{num.LTE(3), "lte:3"},
+		{num.GT(4), "gt:4"},
+		{num.IsNull(), "null:"},
+		{num.NotNull(), "notnull:"},
+		{dex.Equal(25), "eq:25"},
+		{dex.GTE(151), "gte:151"},
+		{dex.IsNull(), "null:"},
+		{date.Equal(day), "eq:2021-01-02"},
+		{date.LTE(day), "lte:2021-01-02"},
+		{date.GT(day), "gt:2021-01-02"},
+		{date.IsNull(), "null:"},
+		{date.NotNull(), "notnull:"},
+	} {
+		if got := tc.pred.params[0].raw(); got != tc.want {
+			t.Fatalf("want %q got %q", tc.want, got)
+		}
+	}
+}
+
+func TestTypedMultiValueFilters(t *testing.T) {
+	q := Cards().Where(
+		CardFields.Types.In("Fire", "Water"),
+		CardFields.Rarity.NotIn("Common", "Uncommon"),
+		CardFields.Name.In("Pikachu"),
+		CardFields.Stage.NotIn("Basic"),
+		CardFields.Types.NotIn("Grass"),
+		CardFields.HP.In(60, 70),
+		CardFields.Retreat.AnyOf(Number.LTE(1), Number.GTE(3)),
+		CardFields.Retreat.AnyOf(),
+	)
+	want := "?types=eq%3AFire|eq%3AWater&rarity=neq%3ACommon&rarity=neq%3AUncommon&name=eq%3APikachu" +
+		"&stage=neq%3ABasic&types=neq%3AGrass&hp=eq%3A60|eq%3A70&retreat=lte%3A1|gte%3A3"
+	if got := q.Build(); got != want {
+		t.Fatalf("unexpected query:\n got: %s\nwant: %s", got, want)
+	}
+}
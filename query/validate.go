@@ -0,0 +1,94 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidationError describes a param of a query that the API would reject or
+// misinterpret.
+type ValidationError struct {
+	Param  string
+	Value  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid param %q (value %q): %s", e.Param, e.Value, e.Reason)
+}
+
+// ValidationErrors lists every problem found by Validate, in param order.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Validate checks the query for mistakes the API would only notice once the
+// request is sent: pagination that is not a positive number, a sort order other
+// than ASC or DESC, repeated sort or pagination params, and empty field names
+// or values. It returns nil or a ValidationErrors.
+func (q *Query) Validate() error {
+	if q == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	fail := func(p param, reason string) {
+		errs = append(errs, &ValidationError{Param: p.key, Value: p.raw(), Reason: reason})
+	}
+	seen := make(map[string]bool)
+	hasSortField, hasSortOrder := false, false
+	for _, p := range q.params {
+		if isControlKey(p.key) {
+			if seen[p.key] {
+				fail(p, "param is repeated")
+			}
+			seen[p.key] = true
+		}
+		switch p.key {
+		case keyPage, keyItemsPerPage:
+			if n, err := strconv.Atoi(p.raw()); err != nil || n < 1 {
+				fail(p, "must be a positive integer")
+			}
+		case keySortField:
+			hasSortField = true
+			if p.raw() == "" {
+				fail(p, "sort field is empty")
+			}
+		case keySortOrder:
+			hasSortOrder = true
+			if o := p.raw(); !strings.EqualFold(o, string(Asc)) && !strings.EqualFold(o, string(Desc)) {
+				fail(p, "sort order must be ASC or DESC")
+			}
+		case "":
+			fail(p, "field name is empty")
+		default:
+			for _, c := range p.conds {
+				if c.Value == "" && c.Op != OpNull && c.Op != OpNotNull {
+					fail(p, "filter value is empty")
+					break
+				}
+			}
+		}
+	}
+	if hasSortOrder && !hasSortField {
+		errs = append(errs, &ValidationError{Param: keySortOrder, Reason: "sort order without a sort field"})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
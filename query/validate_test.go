@@ -0,0 +1,74 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateAcceptsValidQueries(t *testing.T) {
+	queries := []*Query{
+		nil,
+		New(),
+		New().Contains("name", "pika").IsNull("evolveFrom").NotNull("illustrator").
+			Sort("name", "asc").Paginate(1, 50),
+		Cards().Where(CardFields.HP.In(60, 70)).Sort(CardFields.HP, Desc).Query(),
+		New().Sort("name", "DESC").Clone().SetPage(2, 10),
+	}
+	for _, q := range queries {
+		if err := q.Validate(); err != nil {
+			t.Fatalf("%v: unexpected error %v", q, err)
+		}
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	q := New().Paginate(0, -5).Sort("name", "sideways").Sort("hp", "ASC").
+		Equal("types", "").AnyOf("hp", GTE(60), Contains("")).Contains("", "x")
+	err := q.Validate()
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %T %v", err, err)
+	}
+	type problem struct{ param, value string }
+	var got []problem
+	for _, e := range verrs {
+		got = append(got, problem{e.Param, e.Value})
+	}
+	want := []problem{
+		{keyPage, "0"},
+		{keyItemsPerPage, "-5"},
+		{keySortOrder, "sideways"},
+		{keySortField, "hp"},
+		{keySortOrder, "ASC"},
+		{"types", "eq:"},
+		{"hp", "gte:60|"},
+		{"", "x"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v\nwant %v", got, want)
+	}
+
+	var one *ValidationError
+	if !errors.As(err, &one) || one.Param != keyPage || one.Reason != "must be a positive integer" {
+		t.Fatalf("expected first error for the page, got %+v", one)
+	}
+	if want := `invalid param "sort:order" (value "sideways"): sort order must be ASC or DESC`; verrs[2].Error() != want {
+		t.Fatalf("unexpected message %q", verrs[2].Error())
+	}
+}
+
+func TestValidateParsedQuery(t *testing.T) {
+	q, err := Parse("sort%3Aorder=ASC&pagination%3Apage=two")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	var verrs ValidationErrors
+	if !errors.As(q.Validate(), &verrs) || len(verrs) != 2 {
+		t.Fatalf("expected two errors, got %v", verrs)
+	}
+	if verrs[0].Param != keyPage || verrs[1].Reason != "sort order without a sort field" {
+		t.Fatalf("unexpected errors %v", verrs)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/laiambryant/tcgdex/client"
 	"github.com/laiambryant/tcgdex/endpoint"
+	"github.com/laiambryant/tcgdex/enums"
 	"github.com/laiambryant/tcgdex/models"
 )
 
@@ -14,19 +15,78 @@ type TCGDex struct {
 	Card  *endpoint.Endpoint[models.Card, models.CardResume]
 	Set   *endpoint.Endpoint[models.Set, models.SetResume]
 	Serie *endpoint.Endpoint[models.Serie, models.SerieResume]
+
+	Type           *endpoint.StringEndpoint
+	Rarity         *endpoint.StringEndpoint
+	Illustrator    *endpoint.StringEndpoint
+	Category       *endpoint.StringEndpoint
+	HP             *endpoint.StringEndpoint
+	Retreat        *endpoint.StringEndpoint
+	Stage          *endpoint.StringEndpoint
+	Suffix         *endpoint.StringEndpoint
+	RegulationMark *endpoint.StringEndpoint
+	Variant        *endpoint.StringEndpoint
+	DexID          *endpoint.StringEndpoint
+	EnergyType     *endpoint.StringEndpoint
+	TrainerType    *endpoint.StringEndpoint
 }
 
 func New(opts ...client.Option) *TCGDex {
-	c := client.NewHTTPClient(nil, opts...)
+	return newWithClient(client.NewHTTPClient(nil, opts...))
+}
+
+func newWithClient(c *client.Client) *TCGDex {
 	sdk := &TCGDex{
 		Client: c,
 	}
-	sdk.Card = endpoint.New[models.Card, models.CardResume](c, "cards")
-	sdk.Set = endpoint.New[models.Set, models.SetResume](c, "sets")
-	sdk.Serie = endpoint.New[models.Serie, models.SerieResume](c, "series")
+	sdk.Card = endpoint.NewResource[models.Card, models.CardResume](c, "cards", "card")
+	sdk.Set = endpoint.NewResource[models.Set, models.SetResume](c, "sets", "set")
+	sdk.Serie = endpoint.NewResource[models.Serie, models.SerieResume](c, "series", "serie")
+
+	sdk.Type = endpoint.NewString(c, "types")
+	sdk.Rarity = endpoint.NewString(c, "rarities")
+	sdk.Illustrator = endpoint.NewString(c, "illustrators")
+	sdk.Category = endpoint.NewString(c, "categories")
+	sdk.HP = endpoint.NewString(c, "hp")
+	sdk.Retreat = endpoint.NewString(c, "retreats")
+	sdk.Stage = endpoint.NewString(c, "stages")
+	sdk.Suffix = endpoint.NewString(c, "suffixes")
+	sdk.RegulationMark = endpoint.NewString(c, "regulation-marks")
+	sdk.Variant = endpoint.NewString(c, "variants")
+	sdk.DexID = endpoint.NewString(c, "dex-ids")
+	sdk.EnergyType = endpoint.NewString(c, "energy-types")
+	sdk.TrainerType = endpoint.NewString(c, "trainer-types")
 	return sdk
 }
 
+// ForLanguage returns a sibling SDK targeting another locale. It shares the
+// HTTP transport, cache and every other client setting with t.
+func (t *TCGDex) ForLanguage(lang enums.Language) *TCGDex {
+	return newWithClient(t.Client.ForLanguage(lang))
+}
+
+// GetSetCard returns the card with the given local ID in a set, e.g.
+// ("swsh1", "25").
+func (t *TCGDex) GetSetCard(ctx context.Context, setID, localID string) (models.Card, error) {
+	return t.sets().GetCard(ctx, setID, localID)
+}
+
+// FindCard returns the card described by a printed collector number that
+// includes its set, such as "SWSH1 025/202" or "Base Set 4".
+func (t *TCGDex) FindCard(ctx context.Context, printed string) (models.Card, error) {
+	return t.sets().FindCard(ctx, printed)
+}
+
+// FindCardInSet returns the card of a known set from its printed number, such
+// as ("swsh1", "025/202").
+func (t *TCGDex) FindCardInSet(ctx context.Context, setID, printed string) (models.Card, error) {
+	return t.sets().FindCardInSet(ctx, setID, printed)
+}
+
+func (t *TCGDex) sets() *endpoint.SetEndpoint {
+	return &endpoint.SetEndpoint{Endpoint: t.Set}
+}
+
 // GetCardWithPricing is a convenience wrapper that returns a Card with pricing data if available.
 func (t *TCGDex) GetCardWithPricing(ctx context.Context, id string) (models.Card, error) {
 	return t.Card.Get(ctx, id)
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/endpoint"
+	"github.com/laiambryant/tcgdex/enums"
 )
 
 type fakeHTTPClient struct{}
@@ -34,6 +36,26 @@ func TestNewDefaultsAndEndpoints(t *testing.T) {
 	if sdk.Card.Client != sdk.Client || sdk.Set.Client != sdk.Client || sdk.Serie.Client != sdk.Client {
 		t.Fatalf("endpoints should share the same client instance")
 	}
+	secondary := map[string]*endpoint.StringEndpoint{
+		"types":            sdk.Type,
+		"rarities":         sdk.Rarity,
+		"illustrators":     sdk.Illustrator,
+		"categories":       sdk.Category,
+		"hp":               sdk.HP,
+		"retreats":         sdk.Retreat,
+		"stages":           sdk.Stage,
+		"suffixes":         sdk.Suffix,
+		"regulation-marks": sdk.RegulationMark,
+		"variants":         sdk.Variant,
+		"dex-ids":          sdk.DexID,
+		"energy-types":     sdk.EnergyType,
+		"trainer-types":    sdk.TrainerType,
+	}
+	for path, e := range secondary {
+		if e == nil || e.Path != path || e.Client != sdk.Client {
+			t.Fatalf("%s endpoint not initialized correctly: %#v", path, e)
+		}
+	}
 }
 
 func TestNewWithOptionsOverrides(t *testing.T) {
@@ -91,3 +113,55 @@ func TestGetCardWithPricing(t *testing.T) {
 		t.Fatalf("expected tcgplayer normal pricing to be present")
 	}
 }
+
+func TestForLanguage(t *testing.T) {
+	fake := &fakeHTTPClient{}
+	sdk := New(client.WithHTTPClient(fake), client.WithUserAgent("custom-agent"))
+	ja := sdk.ForLanguage(enums.LanguageJa)
+	if ja == sdk || ja.Client == sdk.Client {
+		t.Fatalf("expected a separate SDK and client")
+	}
+	if ja.Client.BaseURL != client.DefaultBaseURL+"/ja" || ja.Client.Language != enums.LanguageJa {
+		t.Fatalf("unexpected language config: %s %s", ja.Client.BaseURL, ja.Client.Language)
+	}
+	if sdk.Client.BaseURL != client.DefaultBaseURL+"/en" {
+		t.Fatalf("original SDK should be untouched, got %s", sdk.Client.BaseURL)
+	}
+	if ja.Client.HTTP != fake || ja.Client.UserAgent != "custom-agent" {
+		t.Fatalf("expected client settings to carry over")
+	}
+	if ja.Card.Client != ja.Client || ja.Set.Client != ja.Client || ja.Serie.Client != ja.Client {
+		t.Fatalf("endpoints should use the localized client")
+	}
+}
+
+type fakeSetCardClient struct{ paths []string }
+
+func (f *fakeSetCardClient) Do(req *http.Request) (*http.Response, error) {
+	f.paths = append(f.paths, req.URL.Path)
+	switch req.URL.Path {
+	case "/en/sets/swsh1/25":
+		return client.NewMockResponse(200, `{"id":"swsh1-25","localId":"25","name":"Charmander"}`), nil
+	}
+	return client.NewMockResponse(404, `{"error":"not found"}`), nil
+}
+
+func TestSetCardLookups(t *testing.T) {
+	f := &fakeSetCardClient{}
+	sdk := New(client.WithBaseURL("http://example/en"), client.WithHTTPClient(f))
+	ctx := context.Background()
+
+	if card, err := sdk.GetSetCard(ctx, "swsh1", "25"); err != nil || card.ID != "swsh1-25" {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+	if card, err := sdk.FindCard(ctx, "SWSH1 025/202"); err != nil || card.ID != "swsh1-25" {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+	f.paths = nil
+	if card, err := sdk.FindCardInSet(ctx, "swsh1", "025/202"); err != nil || card.ID != "swsh1-25" {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+	if len(f.paths) != 2 || f.paths[0] != "/en/sets/swsh1/025" {
+		t.Fatalf("expected printed then unpadded number, got %v", f.paths)
+	}
+}
@@ -0,0 +1,319 @@
+// Package tcgdextest provides a fake TCGDex API server for integration tests.
+// It serves a snapshot in the layout of the mirror package through the
+// offline handler, seeded from fixture files or from models, and can inject
+// faults such as latency, server errors and truncated bodies.
+package tcgdextest
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/laiambryant/tcgdex"
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/mirror"
+	"github.com/laiambryant/tcgdex/models"
+	"github.com/laiambryant/tcgdex/offline"
+)
+
+// Server is a running fake API. Its routes live under /v2/{lang}, as on the
+// public API.
+type Server struct {
+	*httptest.Server
+	Layout mirror.Layout
+
+	mu       sync.Mutex
+	faults   []*fault
+	requests map[string]int
+}
+
+// Fault alters the answers to the requests whose path, relative to the
+// language, starts with Path, e.g. "/cards" or "/sets/base1". An empty Path
+// matches every request.
+type Fault struct {
+	Path string
+	// Latency delays the answer.
+	Latency time.Duration
+	// Status, when set, is returned instead of the resource.
+	Status int
+	// Truncate sends the full Content-Length but only half of the body.
+	Truncate bool
+	// Times limits the fault to that many requests; 0 applies it to all.
+	Times int
+}
+
+type fault struct {
+	Fault
+	hits int
+}
+
+type seed struct {
+	lang     enums.Language
+	resource string
+	id       string
+	item     any
+}
+
+type config struct {
+	fixtures []string
+	seeds    []seed
+}
+
+type Option func(*config)
+
+// WithFixtures copies the JSON files of dir into the server. dir uses the
+// layout of the mirror package, so a full snapshot works as well as a few
+// hand-written items; missing listings are derived from the items.
+func WithFixtures(dir string) Option {
+	return func(c *config) {
+		c.fixtures = append(c.fixtures, dir)
+	}
+}
+
+func WithCards(lang enums.Language, cards ...models.Card) Option {
+	return func(c *config) {
+		for _, card := range cards {
+			c.seeds = append(c.seeds, seed{lang, "cards", card.ID, card})
+		}
+	}
+}
+
+func WithSets(lang enums.Language, sets ...models.Set) Option {
+	return func(c *config) {
+		for _, set := range sets {
+			c.seeds = append(c.seeds, seed{lang, "sets", set.ID, set})
+		}
+	}
+}
+
+func WithSeries(lang enums.Language, series ...models.Serie) Option {
+	return func(c *config) {
+		for _, serie := range series {
+			c.seeds = append(c.seeds, seed{lang, "series", serie.ID, serie})
+		}
+	}
+}
+
+// NewServer starts a server seeded according to opts. It is closed when the
+// test ends.
+func NewServer(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	s := &Server{
+		Layout:   mirror.Layout{Dir: t.TempDir()},
+		requests: make(map[string]int),
+	}
+	if err := s.seed(cfg); err != nil {
+		t.Fatalf("tcgdextest: seeding server: %v", err)
+	}
+	s.Server = httptest.NewServer(s.wrap(offline.New(s.Layout.Dir)))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// BaseURL returns the base URL of the API in lang.
+func (s *Server) BaseURL(lang enums.Language) string {
+	return s.URL + "/v2/" + string(lang)
+}
+
+// SDK returns an SDK talking to the server in English. opts are applied after
+// the base URL, so WithLanguage selects another language.
+func (s *Server) SDK(opts ...client.Option) *tcgdex.TCGDex {
+	return tcgdex.New(append([]client.Option{client.WithBaseURL(s.BaseURL(enums.LanguageEn))}, opts...)...)
+}
+
+// Inject adds a fault. Faults are matched in the order they were added.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{Fault: f})
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns how many requests were received for path, e.g.
+// "/v2/en/cards/base1-4", including the ones answered with a fault.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+func (s *Server) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f := s.match(r.URL.Path)
+		if f == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if f.Latency > 0 {
+			select {
+			case <-time.After(f.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if f.Status != 0 {
+			http.Error(w, http.StatusText(f.Status), f.Status)
+			return
+		}
+		if !f.Truncate {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		body := rec.Body.Bytes()
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(rec.Code)
+		w.Write(body[:len(body)/2])
+	})
+}
+
+// match counts the request and returns the fault to apply to it, if any.
+func (s *Server) match(path string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[path]++
+	rel := relativePath(path)
+	for _, f := range s.faults {
+		if (f.Times > 0 && f.hits >= f.Times) || !strings.HasPrefix(rel, f.Path) {
+			continue
+		}
+		f.hits++
+		matched := f.Fault
+		return &matched
+	}
+	return nil
+}
+
+// relativePath strips the /v2/{lang} prefix from path.
+func relativePath(path string) string {
+	rest := strings.TrimPrefix(path, "/v2/")
+	if i := strings.Index(rest, "/"); i >= 0 {
+		return rest[i:]
+	}
+	return ""
+}
+
+func (s *Server) seed(cfg *config) error {
+	for _, dir := range cfg.fixtures {
+		if err := copyJSON(dir, s.Layout.Dir); err != nil {
+			return err
+		}
+	}
+	seeded := make(map[string]bool)
+	for _, sd := range cfg.seeds {
+		if err := mirror.WriteJSON(s.Layout.Item(sd.lang, sd.resource, sd.id), sd.item); err != nil {
+			return err
+		}
+		seeded[s.Layout.List(sd.lang, sd.resource)] = true
+	}
+
+	langs, err := os.ReadDir(s.Layout.Dir)
+	if err != nil {
+		return err
+	}
+	for _, l := range langs {
+		if !l.IsDir() {
+			continue
+		}
+		lang := enums.Language(l.Name())
+		lists := []struct {
+			resource string
+			build    func(lang enums.Language) (any, error)
+		}{
+			{"cards", s.cardList},
+			{"sets", s.setList},
+			{"series", s.serieList},
+		}
+		for _, list := range lists {
+			path := s.Layout.List(lang, list.resource)
+			if _, err := os.Stat(path); err == nil && !seeded[path] {
+				continue
+			}
+			items, err := list.build(lang)
+			if err != nil {
+				return err
+			}
+			if err := mirror.WriteJSON(path, items); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) cardList(lang enums.Language) (any, error) {
+	return listItems(s.Layout, lang, "cards", func(c models.Card) models.CardResume { return c.CardResume })
+}
+
+func (s *Server) setList(lang enums.Language) (any, error) {
+	return listItems(s.Layout, lang, "sets", func(set models.Set) models.SetResume { return set.SetResume })
+}
+
+func (s *Server) serieList(lang enums.Language) (any, error) {
+	return listItems(s.Layout, lang, "series", func(serie models.Serie) models.SerieResume { return serie.SerieResume })
+}
+
+// listItems builds the listing of resource from its item files, in file name
+// order.
+func listItems[T, L any](l mirror.Layout, lang enums.Language, resource string, resume func(T) L) ([]L, error) {
+	files, err := filepath.Glob(filepath.Join(l.Dir, string(lang), resource, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	out := []L{}
+	for _, f := range files {
+		var item T
+		if err := mirror.ReadJSON(f, &item); err != nil {
+			return nil, err
+		}
+		out = append(out, resume(item))
+	}
+	return out, nil
+}
+
+// copyJSON copies the JSON files found under src to the same relative paths
+// under dst.
+func copyJSON(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
@@ -0,0 +1,109 @@
+package tcgdextest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/laiambryant/tcgdex/client"
+	"github.com/laiambryant/tcgdex/enums"
+	"github.com/laiambryant/tcgdex/models"
+	"github.com/laiambryant/tcgdex/query"
+)
+
+func TestServerServesFixturesAndSeeds(t *testing.T) {
+	pikachu := models.Card{CardResume: models.CardResume{ID: "base1-58", LocalID: "58", Name: "Pikachu"}, Types: []string{"Lightning"}}
+	srv := NewServer(t, WithFixtures("testdata/fixtures"), WithCards(enums.LanguageEn, pikachu),
+		WithSeries(enums.LanguageEn, models.Serie{SerieResume: models.SerieResume{ID: "base", Name: "Base"}}))
+	sdk := srv.SDK()
+	ctx := context.Background()
+
+	card, err := sdk.Card.Get(ctx, "base1-4")
+	if err != nil || card.Name != "Charizard" {
+		t.Fatalf("unexpected card %+v %v", card, err)
+	}
+	cards, err := sdk.Card.List(ctx, query.New().In("types", "Water", "Lightning").Sort("name", "ASC"))
+	if err != nil || len(cards) != 2 || cards[0].ID != "base1-2" || cards[1].ID != "base1-58" {
+		t.Fatalf("unexpected cards %+v %v", cards, err)
+	}
+	all, err := sdk.Card.List(ctx, query.New().Paginate(1, 2))
+	if err != nil || len(all) != 2 {
+		t.Fatalf("unexpected page %+v %v", all, err)
+	}
+	byNumber, err := sdk.GetSetCard(ctx, "base1", "2")
+	if err != nil || byNumber.Name != "Blastoise" {
+		t.Fatalf("unexpected card by number %+v %v", byNumber, err)
+	}
+	series, err := sdk.Serie.List(ctx, nil)
+	if err != nil || len(series) != 1 {
+		t.Fatalf("unexpected series %+v %v", series, err)
+	}
+
+	fr, err := sdk.ForLanguage(enums.LanguageFr).Card.Get(ctx, "base1-4")
+	if err != nil || fr.Name != "Dracaufeu" {
+		t.Fatalf("unexpected french card %+v %v", fr, err)
+	}
+	if _, err := sdk.Card.Get(ctx, "base1-999"); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := sdk.ForLanguage(enums.LanguageDe).Set.Get(ctx, "base1"); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unseeded language, got %v", err)
+	}
+	if n := srv.Requests("/v2/en/cards/base1-4"); n != 1 {
+		t.Fatalf("expected one request for the card, got %d", n)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	srv := NewServer(t, WithFixtures("testdata/fixtures"))
+	ctx := context.Background()
+
+	t.Run("Status", func(t *testing.T) {
+		srv.Inject(Fault{Path: "/cards/base1-4", Status: http.StatusServiceUnavailable, Times: 1})
+		defer srv.ClearFaults()
+		policy := client.DefaultRetryPolicy()
+		policy.BaseDelay = time.Millisecond
+		sdk := srv.SDK(client.WithRetry(policy))
+
+		before := srv.Requests("/v2/en/cards/base1-4")
+		card, err := sdk.Card.Get(ctx, "base1-4")
+		if err != nil || card.Name != "Charizard" {
+			t.Fatalf("expected the retry to succeed, got %+v %v", card, err)
+		}
+		if n := srv.Requests("/v2/en/cards/base1-4") - before; n != 2 {
+			t.Fatalf("expected two requests, got %d", n)
+		}
+	})
+
+	t.Run("StatusOnEveryRequest", func(t *testing.T) {
+		srv.Inject(Fault{Path: "/sets", Status: http.StatusInternalServerError})
+		defer srv.ClearFaults()
+		_, err := srv.SDK().Set.Get(ctx, "base1")
+		var he *client.HTTPError
+		if !errors.As(err, &he) || he.Status != http.StatusInternalServerError {
+			t.Fatalf("expected a 500, got %v", err)
+		}
+	})
+
+	t.Run("Latency", func(t *testing.T) {
+		srv.Inject(Fault{Latency: time.Second})
+		defer srv.ClearFaults()
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if _, err := srv.SDK().Card.Get(ctx, "base1-2"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a deadline error, got %v", err)
+		}
+	})
+
+	t.Run("Truncate", func(t *testing.T) {
+		srv.Inject(Fault{Path: "/cards", Truncate: true})
+		defer srv.ClearFaults()
+		_, err := srv.SDK().Card.Get(ctx, "base1-4")
+		var re *client.RequestError
+		if !errors.As(err, &re) || re.Op != "read body" {
+			t.Fatalf("expected a read error, got %v", err)
+		}
+	})
+}